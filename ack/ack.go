@@ -0,0 +1,51 @@
+// Package ack models items which must be acknowledged once processed, such
+// as messages from a broker, so that a source only commits or removes them
+// after downstream processing succeeded.
+package ack
+
+import (
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Ackable is an item to acknowledge once processed. Ack signals success and
+// Nack failure, letting the source redeliver the item or give up on it
+// according to its own semantics.
+type Ackable interface {
+	Ack() error
+	Nack(cause error) error
+}
+
+// Process subscribes process to the items of o, then acknowledges each
+// Ackable item: Ack if process returns nil, Nack with the error otherwise.
+// An error returned by process doesn't stop the subscription, but an error
+// from Ack or Nack does, as does an error emitted by o; the Subscription
+// records it.
+func Process(o observable.Observable, process func(item interface{}) error) <-chan subscription.Subscription {
+	done := make(chan subscription.Subscription, 1)
+	go func() {
+		sub := subscription.New().Subscribe()
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				sub.Error = err
+				break
+			}
+			err := process(item)
+			a, ok := item.(Ackable)
+			if !ok {
+				continue
+			}
+			if err == nil {
+				err = a.Ack()
+			} else {
+				err = a.Nack(err)
+			}
+			if err != nil {
+				sub.Error = err
+				break
+			}
+		}
+		done <- sub.Unsubscribe()
+	}()
+	return done
+}
@@ -0,0 +1,56 @@
+package ack
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type item struct {
+	value  int
+	acked  bool
+	nacked error
+	ackErr error
+}
+
+func (i *item) Ack() error {
+	i.acked = true
+	return i.ackErr
+}
+
+func (i *item) Nack(cause error) error {
+	i.nacked = cause
+	return nil
+}
+
+func TestProcess(t *testing.T) {
+	items := []*item{{value: 1}, {value: 2}, {value: 3}}
+	var processed []interface{}
+	sub := <-Process(observable.Just(items[0], items[1], items[2], "plain"), func(i interface{}) error {
+		processed = append(processed, i)
+		if i, ok := i.(*item); ok && i.value == 2 {
+			return errors.New("invalid")
+		}
+		return nil
+	})
+
+	assert.Nil(t, sub.Err())
+	assert.Len(t, processed, 4)
+	assert.True(t, items[0].acked)
+	assert.False(t, items[1].acked)
+	assert.EqualError(t, items[1].nacked, "invalid")
+	assert.True(t, items[2].acked)
+}
+
+func TestProcessErrors(t *testing.T) {
+	failing := &item{ackErr: errors.New("commit failed")}
+	next := &item{}
+	sub := <-Process(observable.Just(failing, next), func(interface{}) error { return nil })
+	assert.EqualError(t, sub.Err(), "commit failed")
+	assert.False(t, next.acked)
+
+	sub = <-Process(observable.Just(errors.New("bang")), func(interface{}) error { return nil })
+	assert.EqualError(t, sub.Err(), "bang")
+}
@@ -0,0 +1,229 @@
+// Package bus provides an in-process topic bus: named streams which any
+// number of Observables can subscribe to and publish on, and which can be
+// looked up and listed by name for diagnostics.
+package bus
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/reactivex/rxgo/observable"
+)
+
+// Bus is a registry of named Topics.
+type Bus struct {
+	mu     sync.Mutex
+	topics map[string]*Topic
+}
+
+// NewBus creates an empty Bus.
+func NewBus() *Bus {
+	return &Bus{topics: make(map[string]*Topic)}
+}
+
+// Default is the Bus used by the package-level functions.
+var Default = NewBus()
+
+// Topic returns the Topic called name, creating it if needed.
+func (b *Bus) Topic(name string) *Topic {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[name]
+	if !ok {
+		t = &Topic{name: name, bus: b, subscribers: make(map[observable.Observable]*subscriber)}
+		b.topics[name] = t
+	}
+	return t
+}
+
+// Lookup returns the Topic called name, if it exists.
+func (b *Bus) Lookup(name string) (*Topic, bool) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	t, ok := b.topics[name]
+	return t, ok
+}
+
+// Names returns the sorted names of the Topics of the Bus.
+func (b *Bus) Names() []string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	names := make([]string, 0, len(b.topics))
+	for name := range b.topics {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Publish publishes item on the Topic called name.
+func (b *Bus) Publish(name string, item interface{}) {
+	b.Topic(name).Publish(item)
+}
+
+// Subscribe subscribes to the Topic called name.
+func (b *Bus) Subscribe(name string, buffer ...uint) observable.Observable {
+	return b.Topic(name).Subscribe(buffer...)
+}
+
+// Register publishes every item of o on the Topic called name, and closes
+// the Topic once o completes.
+func (b *Bus) Register(name string, o observable.Observable) *Topic {
+	t := b.Topic(name)
+	go func() {
+		for item := range o {
+			t.Publish(item)
+		}
+		t.Close()
+	}()
+	return t
+}
+
+func (b *Bus) remove(t *Topic) {
+	b.mu.Lock()
+	if b.topics[t.name] == t {
+		delete(b.topics, t.name)
+	}
+	b.mu.Unlock()
+}
+
+// Lookup returns the Topic called name on the Default Bus, if it exists.
+func Lookup(name string) (*Topic, bool) {
+	return Default.Lookup(name)
+}
+
+// Names returns the sorted names of the Topics of the Default Bus.
+func Names() []string {
+	return Default.Names()
+}
+
+// Publish publishes item on the Topic called name on the Default Bus.
+func Publish(name string, item interface{}) {
+	Default.Publish(name, item)
+}
+
+// Subscribe subscribes to the Topic called name on the Default Bus.
+func Subscribe(name string, buffer ...uint) observable.Observable {
+	return Default.Subscribe(name, buffer...)
+}
+
+// Register publishes every item of o on the Topic called name on the
+// Default Bus.
+func Register(name string, o observable.Observable) *Topic {
+	return Default.Register(name, o)
+}
+
+// Topic is a named stream broadcasting every published item to all its
+// subscribers.
+type Topic struct {
+	name string
+	bus  *Bus
+
+	// publishing serializes Publish, and is held while delivering so that
+	// subscriber channels are only closed between deliveries.
+	publishing sync.Mutex
+
+	mu          sync.Mutex
+	subscribers map[observable.Observable]*subscriber
+	closed      bool
+}
+
+type subscriber struct {
+	ch   chan interface{}
+	done chan struct{}
+}
+
+// Name returns the name of the Topic.
+func (t *Topic) Name() string {
+	return t.name
+}
+
+// Subscribe returns an Observable of the items published on the Topic from
+// now on, buffering up to an optional number of items, 0 by default. The
+// Observable completes when the Topic is closed or on Unsubscribe.
+func (t *Topic) Subscribe(buffer ...uint) observable.Observable {
+	var size uint
+	if len(buffer) > 0 {
+		size = buffer[0]
+	}
+	s := &subscriber{ch: make(chan interface{}, int(size)), done: make(chan struct{})}
+	o := observable.Observable(s.ch)
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		close(s.ch)
+		return o
+	}
+	t.subscribers[o] = s
+	return o
+}
+
+// Unsubscribe completes an Observable returned by Subscribe and stops
+// delivering items to it.
+func (t *Topic) Unsubscribe(o observable.Observable) {
+	t.mu.Lock()
+	s, ok := t.subscribers[o]
+	delete(t.subscribers, o)
+	t.mu.Unlock()
+	if ok {
+		t.release([]*subscriber{s})
+	}
+}
+
+// release completes subscribers which are no longer registered, first
+// unblocking any delivery in progress to them.
+func (t *Topic) release(subscribers []*subscriber) {
+	for _, s := range subscribers {
+		close(s.done)
+	}
+	t.publishing.Lock()
+	for _, s := range subscribers {
+		close(s.ch)
+	}
+	t.publishing.Unlock()
+}
+
+// Publish delivers item to every subscriber of the Topic. It blocks until
+// each subscriber has received the item or buffered it, so a slow
+// subscriber slows down publishers. Items published on a closed Topic are
+// discarded.
+func (t *Topic) Publish(item interface{}) {
+	t.publishing.Lock()
+	defer t.publishing.Unlock()
+
+	t.mu.Lock()
+	subscribers := make([]*subscriber, 0, len(t.subscribers))
+	for _, s := range t.subscribers {
+		subscribers = append(subscribers, s)
+	}
+	t.mu.Unlock()
+
+	for _, s := range subscribers {
+		select {
+		case s.ch <- item:
+		case <-s.done:
+		}
+	}
+}
+
+// Subscribers returns the number of subscribers of the Topic.
+func (t *Topic) Subscribers() int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return len(t.subscribers)
+}
+
+// Close completes every subscriber and removes the Topic from its Bus.
+func (t *Topic) Close() {
+	t.mu.Lock()
+	t.closed = true
+	subscribers := make([]*subscriber, 0, len(t.subscribers))
+	for o, s := range t.subscribers {
+		delete(t.subscribers, o)
+		subscribers = append(subscribers, s)
+	}
+	t.mu.Unlock()
+	t.release(subscribers)
+	t.bus.remove(t)
+}
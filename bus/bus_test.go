@@ -0,0 +1,89 @@
+package bus
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusPublishSubscribe(t *testing.T) {
+	b := NewBus()
+	first := b.Subscribe("prices", 2)
+	second := b.Subscribe("prices", 2)
+
+	topic, ok := b.Lookup("prices")
+	assert.True(t, ok)
+	assert.Equal(t, "prices", topic.Name())
+	assert.Equal(t, 2, topic.Subscribers())
+
+	b.Publish("prices", 1)
+	b.Publish("prices", 2)
+	topic.Close()
+
+	items, err := first.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, items)
+	items, err = second.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, items)
+
+	_, ok = b.Lookup("prices")
+	assert.False(t, ok)
+}
+
+func TestBusRegister(t *testing.T) {
+	b := NewBus()
+	topic := b.Topic("numbers")
+	sub := topic.Subscribe()
+	assert.Equal(t, []string{"numbers"}, b.Names())
+
+	b.Register("numbers", observable.Just(1, 2, 3))
+	items, err := sub.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, items)
+}
+
+func TestTopicUnsubscribe(t *testing.T) {
+	b := NewBus()
+	topic := b.Topic("events")
+	slow := topic.Subscribe()
+	fast := topic.Subscribe(1)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		// Blocks on the slow subscriber until it unsubscribes.
+		topic.Publish("a")
+	}()
+
+	time.Sleep(10 * time.Millisecond)
+	topic.Unsubscribe(slow)
+	wg.Wait()
+	assert.Equal(t, "a", <-fast)
+	_, ok := <-slow
+	assert.False(t, ok)
+	assert.Equal(t, 1, topic.Subscribers())
+
+	topic.Close()
+	_, ok = <-fast
+	assert.False(t, ok)
+	items, _ := topic.Subscribe().ToSlice()
+	assert.Empty(t, items)
+}
+
+func TestDefaultBus(t *testing.T) {
+	sub := Subscribe("default-test", 1)
+	Publish("default-test", "x")
+	topic, ok := Lookup("default-test")
+	assert.True(t, ok)
+	assert.Contains(t, Names(), "default-test")
+	topic.Close()
+
+	items, err := sub.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"x"}, items)
+}
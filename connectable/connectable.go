@@ -52,6 +52,19 @@ func Empty() Connectable {
 	return Connectable{Observable: source}
 }
 
+// Never creates a Connectable which emits no item and never terminates.
+func Never() Connectable {
+	return Connectable{Observable: make(chan interface{})}
+}
+
+// Throw creates a Connectable which emits an error and terminates immediately.
+func Throw(err error) Connectable {
+	source := make(chan interface{}, 1)
+	source <- err
+	close(source)
+	return Connectable{Observable: source}
+}
+
 // Interval creates a Connectable emitting incremental integers infinitely between
 // each given time interval.
 func Interval(term chan struct{}, timeout time.Duration) Connectable {
@@ -74,7 +87,8 @@ func Interval(term chan struct{}, timeout time.Duration) Connectable {
 	return Connectable{Observable: source}
 }
 
-// Range creates an Connectable that emits a particular range of sequential integers.
+// Range creates an Connectable that emits a particular range of sequential integers,
+// from start up to but excluding end. The integers are generated lazily.
 func Range(start, end int) Connectable {
 	source := make(chan interface{})
 	go func() {
@@ -178,6 +192,8 @@ func (co Connectable) Connect() <-chan (chan subscription.Subscription) {
 					// Record error
 					sub.Error = item
 					break OuterLoop
+				case observable.Batch:
+					ob.OnNextBatch(item)
 				default:
 					ob.OnNext(item)
 				}
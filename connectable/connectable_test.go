@@ -240,6 +240,19 @@ func TestSubscribeToManyObservers(t *testing.T) {
 	assert.Empty(dones)
 }
 
+func TestConnectableThrow(t *testing.T) {
+	errText := ""
+	onError := handlers.ErrFunc(func(err error) {
+		errText = err.Error()
+	})
+
+	co := Throw(errors.New("bang")).Subscribe(onError)
+	sub := co.Connect()
+	<-sub
+
+	assert.Equal(t, "bang", errText)
+}
+
 func TestConnectableMap(t *testing.T) {
 	items := []interface{}{1, 2, 3, "foo", "bar", []byte("baz")}
 	it, err := iterable.New(items)
@@ -14,6 +14,8 @@ var errcodes = []ErrorCode{
 	ObserverError,
 	IterableError,
 	UndefinedError,
+	BackpressureError,
+	NoSuchElementError,
 }
 
 func TestErrorCodes(t *testing.T) {
@@ -0,0 +1,126 @@
+// Package flowable provides a Flowable, a pull-based stream whose subscriber
+// explicitly requests how many items it is ready to receive.
+package flowable
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/reactivex/rxgo"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/observer"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Unbounded requests every remaining item of a Flowable.
+const Unbounded = ^uint64(0)
+
+// Flowable pulls items from an Iterator only when its subscriber has
+// requested them.
+type Flowable struct {
+	it rx.Iterator
+}
+
+// From creates a Flowable from an Iterator. Observable implements Iterator,
+// so any Observable can be turned into a Flowable.
+func From(it rx.Iterator) Flowable {
+	return Flowable{it: it}
+}
+
+// Subscription lets the subscriber of a Flowable signal demand or cancel it.
+type Subscription struct {
+	demand uint64
+	wake   chan struct{}
+	cancel chan struct{}
+	once   sync.Once
+	done   chan subscription.Subscription
+}
+
+// Request signals that the subscriber is ready for n more items. It is safe to
+// call from within the subscriber's handlers.
+func (s *Subscription) Request(n uint64) {
+	for {
+		current := atomic.LoadUint64(&s.demand)
+		next := current + n
+		if next < current {
+			next = Unbounded
+		}
+		if atomic.CompareAndSwapUint64(&s.demand, current, next) {
+			break
+		}
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Cancel stops the Flowable from pulling any more items. OnDone is not called
+// on a cancelled subscription.
+func (s *Subscription) Cancel() {
+	s.once.Do(func() {
+		close(s.cancel)
+	})
+}
+
+// Done returns a channel which emits the Subscription once the Flowable has
+// terminated or been cancelled.
+func (s *Subscription) Done() <-chan subscription.Subscription {
+	return s.done
+}
+
+// take blocks until there is outstanding demand, consumes one unit of it and
+// reports whether the subscription is still active.
+func (s *Subscription) take() bool {
+	for {
+		select {
+		case <-s.cancel:
+			return false
+		default:
+		}
+
+		current := atomic.LoadUint64(&s.demand)
+		if current == 0 {
+			select {
+			case <-s.wake:
+			case <-s.cancel:
+				return false
+			}
+			continue
+		}
+		if current == Unbounded || atomic.CompareAndSwapUint64(&s.demand, current, current-1) {
+			return true
+		}
+	}
+}
+
+// Subscribe subscribes an EventHandler to the Flowable. No item is pulled
+// until Request is called on the returned Subscription.
+func (f Flowable) Subscribe(handler rx.EventHandler) *Subscription {
+	s := &Subscription{
+		wake:   make(chan struct{}, 1),
+		cancel: make(chan struct{}),
+		done:   make(chan subscription.Subscription, 1),
+	}
+	sub := subscription.New().Subscribe()
+	ob := observable.CheckEventHandler(handler)
+
+	go func(ob observer.Observer) {
+		for s.take() {
+			item, err := f.it.Next()
+			if err != nil {
+				ob.OnDone()
+				break
+			}
+			if err, isErr := item.(error); isErr {
+				ob.OnError(err)
+				sub.Error = err
+				break
+			}
+			ob.OnNext(item)
+		}
+		s.done <- sub.Unsubscribe()
+	}(ob)
+
+	return s
+}
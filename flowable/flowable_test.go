@@ -0,0 +1,106 @@
+package flowable
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/iterable"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// countingIterator counts how many items have been pulled from it.
+type countingIterator struct {
+	it     iterable.Iterable
+	pulled int64
+}
+
+func (c *countingIterator) Next() (interface{}, error) {
+	item, err := c.it.Next()
+	if err == nil {
+		atomic.AddInt64(&c.pulled, 1)
+	}
+	return item, err
+}
+
+func TestFlowablePullsOnlyRequestedItems(t *testing.T) {
+	it, err := iterable.New([]interface{}{1, 2, 3, 4, 5})
+	if err != nil {
+		t.Fail()
+	}
+	counter := &countingIterator{it: it}
+
+	received := make(chan int, 5)
+	onNext := handlers.NextFunc(func(item interface{}) {
+		received <- item.(int)
+	})
+
+	s := From(counter).Subscribe(onNext)
+	<-time.After(10 * time.Millisecond)
+	assert.EqualValues(t, 0, atomic.LoadInt64(&counter.pulled))
+
+	s.Request(2)
+	assert.Equal(t, 1, <-received)
+	assert.Equal(t, 2, <-received)
+	<-time.After(10 * time.Millisecond)
+	assert.EqualValues(t, 2, atomic.LoadInt64(&counter.pulled))
+
+	s.Request(Unbounded)
+	sub := <-s.Done()
+	assert.Nil(t, sub.Err())
+	assert.EqualValues(t, 5, atomic.LoadInt64(&counter.pulled))
+}
+
+func TestFlowableRequestFromHandler(t *testing.T) {
+	nums := []int{}
+	done := false
+
+	var s *Subscription
+	onNext := handlers.NextFunc(func(item interface{}) {
+		nums = append(nums, item.(int))
+		s.Request(1)
+	})
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+
+	s = From(observable.Range(0, 4)).Subscribe(observer.New(onNext, onDone))
+	s.Request(1)
+	<-s.Done()
+
+	assert.Exactly(t, []int{0, 1, 2, 3}, nums)
+	assert.True(t, done)
+}
+
+func TestFlowableWithError(t *testing.T) {
+	errText := ""
+	onError := handlers.ErrFunc(func(err error) {
+		errText = err.Error()
+	})
+
+	s := From(observable.Just(1, errors.New("bang"), 2)).Subscribe(onError)
+	s.Request(Unbounded)
+	sub := <-s.Done()
+
+	assert.Equal(t, "bang", errText)
+	assert.NotNil(t, sub.Err())
+}
+
+func TestFlowableCancel(t *testing.T) {
+	done := false
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+
+	s := From(observable.Range(0, 10)).Subscribe(onDone)
+	s.Cancel()
+	s.Cancel()
+	<-s.Done()
+
+	assert.False(t, done)
+}
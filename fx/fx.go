@@ -2,6 +2,8 @@
 // such as Map, Filter, Scan, and Start.
 package fx
 
+import "time"
+
 type (
 
 	// EmittableFunc defines a function that should be used with Start operator.
@@ -16,7 +18,44 @@ type (
 
 	// FilterableFunc defines a func that should be passed to the Filter operator.
 	FilterableFunc func(interface{}) bool
+
+	// MappableErrFunc defines a fallible func that acts as a predicate to the
+	// MapE operator.
+	MappableErrFunc func(interface{}) (interface{}, error)
+
+	// StatefulMappableFunc defines a func that maps an item given the state
+	// kept for its key, returning the new state and the output, to be used
+	// with the MapWithState operator.
+	StatefulMappableFunc func(state interface{}, item interface{}) (interface{}, interface{})
+
+	// FilterableErrFunc defines a fallible func that should be passed to the
+	// FilterE operator.
+	FilterableErrFunc func(interface{}) (bool, error)
 		
 	// KeySelectorFunc defines a func that should be passed to the Distinct operator.
 	KeySelectorFunc func(interface{}) interface{}
+
+	// EqualityFunc defines a func that reports whether two items are equal,
+	// to be used with the Contains operator.
+	EqualityFunc func(interface{}, interface{}) bool
+
+	// LessFunc defines a func that reports whether the first item orders
+	// before the second, to be used with the TopK operator.
+	LessFunc func(interface{}, interface{}) bool
+
+	// TimestampFunc defines a func that extracts the event time of an item,
+	// to be used with the EventTimeWindowAggregate operator.
+	TimestampFunc func(interface{}) time.Time
+
+	// CombinableFunc defines a func that combines an item from each of two
+	// Observables, to be used with the Join operator.
+	CombinableFunc func(interface{}, interface{}) interface{}
+
+	// SizeFunc defines a func that measures an item, for example in
+	// serialized bytes, to be used with the Chunk operator.
+	SizeFunc func(interface{}) uint
+
+	// AccumulatorFunc defines a func that adds an item to a mutable container,
+	// to be used with the Collect operator.
+	AccumulatorFunc func(container interface{}, item interface{})
 )
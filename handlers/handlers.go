@@ -10,10 +10,16 @@ type (
 
 	// DoneFunc handles the end of a stream.
 	DoneFunc func()
+
+	// BatchFunc handles a batch of next items in a stream.
+	BatchFunc func([]interface{})
 )
 
-// Handle registers NextFunc to EventHandler.
+// Handle registers NextFunc to EventHandler. A nil NextFunc ignores items.
 func (handle NextFunc) Handle(item interface{}) {
+	if handle == nil {
+		return
+	}
 	switch item := item.(type) {
 	case error:
 		return
@@ -22,8 +28,11 @@ func (handle NextFunc) Handle(item interface{}) {
 	}
 }
 
-// Handle registers ErrFunc to EventHandler.
+// Handle registers ErrFunc to EventHandler. A nil ErrFunc ignores errors.
 func (handle ErrFunc) Handle(item interface{}) {
+	if handle == nil {
+		return
+	}
 	switch item := item.(type) {
 	case error:
 		handle(item)
@@ -32,7 +41,26 @@ func (handle ErrFunc) Handle(item interface{}) {
 	}
 }
 
-// Handle registers DoneFunc to EventHandler.
+// Handle registers BatchFunc to EventHandler. A single item is handled as a
+// batch of one. A nil BatchFunc ignores items.
+func (handle BatchFunc) Handle(item interface{}) {
+	if handle == nil {
+		return
+	}
+	switch item := item.(type) {
+	case error:
+		return
+	case []interface{}:
+		handle(item)
+	default:
+		handle([]interface{}{item})
+	}
+}
+
+// Handle registers DoneFunc to EventHandler. A nil DoneFunc does nothing.
 func (handle DoneFunc) Handle(item interface{}) {
+	if handle == nil {
+		return
+	}
 	handle()
 }
@@ -14,6 +14,7 @@ func TestHandlersImplementEventHandler(t *testing.T) {
 	assert.Implements((*rx.EventHandler)(nil), (*NextFunc)(nil))
 	assert.Implements((*rx.EventHandler)(nil), (*ErrFunc)(nil))
 	assert.Implements((*rx.EventHandler)(nil), (*DoneFunc)(nil))
+	assert.Implements((*rx.EventHandler)(nil), (*BatchFunc)(nil))
 }
 
 func TestNextFuncHandleMethod(t *testing.T) {
@@ -103,3 +104,38 @@ func TestDoneFuncHandleMethod(t *testing.T) {
 		assert.Equal(samples[n], "DONE")
 	}
 }
+
+func TestBatchFuncHandleMethod(t *testing.T) {
+	batches := [][]interface{}{}
+
+	batchf := BatchFunc(func(items []interface{}) {
+		batches = append(batches, items)
+	})
+
+	batchHandleTests := []interface{}{
+		[]interface{}{1, 2},
+		errors.New("Anonymous error"),
+		"Hello",
+	}
+
+	for _, tt := range batchHandleTests {
+		batchf.Handle(tt)
+	}
+
+	expected := [][]interface{}{{1, 2}, {"Hello"}}
+	assert.Exactly(t, expected, batches)
+}
+
+func TestNilHandlersAreSafe(t *testing.T) {
+	var next NextFunc
+	var fail ErrFunc
+	var done DoneFunc
+	var batch BatchFunc
+
+	for _, item := range []interface{}{1, errors.New("bang")} {
+		next.Handle(item)
+		fail.Handle(item)
+		done.Handle(item)
+		batch.Handle(item)
+	}
+}
@@ -0,0 +1,48 @@
+// Package connector holds the plumbing shared by the connector packages,
+// which bridge Observables to messaging systems.
+package connector
+
+import (
+	"encoding/json"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Sink calls publish with each item of o, then the optional flush once o
+// completes, for instance to wait for pending acknowledgements. The
+// returned channel receives the Subscription, recording the error of o or
+// the first error returned by publish or flush, which stops the sink.
+func Sink(o observable.Observable, publish func(item interface{}) error, flush ...func() error) <-chan subscription.Subscription {
+	done := make(chan subscription.Subscription, 1)
+	go func() {
+		sub := subscription.New().Subscribe()
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				sub.Error = err
+				break
+			}
+			if sub.Error = publish(item); sub.Error != nil {
+				break
+			}
+		}
+		if sub.Error == nil && len(flush) > 0 {
+			sub.Error = flush[0]()
+		}
+		done <- sub.Unsubscribe()
+	}()
+	return done
+}
+
+// Payload returns the bytes of a message carrying item: []byte and strings
+// as they are, and any other item as its JSON encoding.
+func Payload(item interface{}) ([]byte, error) {
+	switch item := item.(type) {
+	case []byte:
+		return item, nil
+	case string:
+		return []byte(item), nil
+	default:
+		return json.Marshal(item)
+	}
+}
@@ -0,0 +1,49 @@
+package connector
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSink(t *testing.T) {
+	var published []interface{}
+	flushed := false
+	sub := <-Sink(observable.Just(1, 2), func(item interface{}) error {
+		published = append(published, item)
+		return nil
+	}, func() error {
+		flushed = true
+		return nil
+	})
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []interface{}{1, 2}, published)
+	assert.True(t, flushed)
+}
+
+func TestSinkError(t *testing.T) {
+	flushed := false
+	flush := func() error {
+		flushed = true
+		return nil
+	}
+	sub := <-Sink(observable.Just(1, 2), func(interface{}) error { return errors.New("unavailable") }, flush)
+	assert.EqualError(t, sub.Err(), "unavailable")
+	sub = <-Sink(observable.Throw(errors.New("bang")), func(interface{}) error { return nil }, flush)
+	assert.EqualError(t, sub.Err(), "bang")
+	assert.False(t, flushed)
+}
+
+func TestPayload(t *testing.T) {
+	for item, want := range map[interface{}]string{"a": "a", 1: "1", true: "true"} {
+		payload, err := Payload(item)
+		assert.Nil(t, err)
+		assert.Equal(t, want, string(payload))
+	}
+	payload, _ := Payload([]byte("b"))
+	assert.Equal(t, "b", string(payload))
+	_, err := Payload(func() {})
+	assert.NotNil(t, err)
+}
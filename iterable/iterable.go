@@ -17,17 +17,21 @@ func (it Iterable) Next() (interface{}, error) {
 }
 
 // New creates a new Iterable from a slice or a channel of empty interface.
+// Slices of int, int64, float64 and string are accepted too, and converted
+// element by element without reflection. Each of their elements is boxed
+// into an empty interface as it is sent, which allocates for most values.
 func New(any interface{}) (Iterable, error) {
 	switch any := any.(type) {
 	case []interface{}:
-		c := make(chan interface{}, len(any))
-		go func() {
-			for _, val := range any {
-				c <- val
-			}
-			close(c)
-		}()
-		return Iterable(c), nil
+		return feed(len(any), func(i int) interface{} { return any[i] }), nil
+	case []int:
+		return feed(len(any), func(i int) interface{} { return any[i] }), nil
+	case []int64:
+		return feed(len(any), func(i int) interface{} { return any[i] }), nil
+	case []float64:
+		return feed(len(any), func(i int) interface{} { return any[i] }), nil
+	case []string:
+		return feed(len(any), func(i int) interface{} { return any[i] }), nil
 	case chan interface{}:
 		return Iterable(any), nil
 	case <-chan interface{}:
@@ -36,3 +40,39 @@ func New(any interface{}) (Iterable, error) {
 		return nil, errors.New(errors.IterableError)
 	}
 }
+
+// feed returns an Iterable of the n elements returned by at, sent from a
+// goroutine into a channel buffering all of them.
+func feed(n int, at func(i int) interface{}) Iterable {
+	c := make(chan interface{}, n)
+	go func() {
+		for i := 0; i < n; i++ {
+			c <- at(i)
+		}
+		close(c)
+	}()
+	return Iterable(c)
+}
+
+// Slice is an Iterator over a slice of empty interface which iterates in
+// place, without the goroutine and channel behind an Iterable.
+type Slice struct {
+	items []interface{}
+	next  int
+}
+
+// NewSlice creates a Slice iterating over items.
+func NewSlice(items []interface{}) *Slice {
+	return &Slice{items: items}
+}
+
+// Next returns the next element of the Slice and an error once it reaches
+// the end.
+func (s *Slice) Next() (interface{}, error) {
+	if s.next >= len(s.items) {
+		return nil, errors.New(errors.EndOfIteratorError)
+	}
+	item := s.items[s.next]
+	s.next++
+	return item, nil
+}
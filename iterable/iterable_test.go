@@ -97,3 +97,41 @@ func TestCreateHeterogeneousIterable(t *testing.T) {
 		}
 	}
 }
+
+func TestCreateIterableFromTypedSlices(t *testing.T) {
+	for _, slice := range []interface{}{
+		[]int{1, 2},
+		[]int64{1, 2},
+		[]float64{1, 2},
+		[]string{"1", "2"},
+	} {
+		it, err := New(slice)
+		assert.Nil(t, err)
+
+		var items []interface{}
+		for {
+			item, err := it.Next()
+			if err != nil {
+				break
+			}
+			items = append(items, item)
+		}
+		assert.Len(t, items, 2)
+	}
+}
+
+func TestSliceIterator(t *testing.T) {
+	assert.Implements(t, (*rx.Iterator)(nil), NewSlice(nil))
+
+	source := []interface{}{1, "two"}
+	it := NewSlice(source)
+	item, err := it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+	item, err = it.Next()
+	assert.Nil(t, err)
+	assert.Equal(t, "two", item)
+	_, err = it.Next()
+	assert.NotNil(t, err)
+	assert.Equal(t, []interface{}{1, "two"}, source)
+}
@@ -0,0 +1,126 @@
+// Package metrics instruments Observables with counters, gauges and
+// histograms. Its interfaces are satisfied by the metrics of the Prometheus
+// client, so that a pipeline can report to a prometheus.Registerer without
+// this package depending on it:
+//
+//	emitted := prometheus.NewCounterVec(prometheus.CounterOpts{Name: "rxgo_emitted_total"}, []string{"observable"})
+//	latency := prometheus.NewHistogramVec(prometheus.HistogramOpts{Name: "rxgo_onnext_seconds"}, []string{"observable"})
+//	registry.MustRegister(emitted, latency)
+//
+//	o = metrics.Instrument(o, metrics.Metrics{
+//		Emitted: emitted.WithLabelValues("prices"),
+//		Latency: latency.WithLabelValues("prices"),
+//	})
+//	<-o.Subscribe(onPrice)
+package metrics
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/observer"
+)
+
+// Counter is a monotonically increasing metric, such as prometheus.Counter.
+type Counter interface {
+	Inc()
+}
+
+// Gauge is a metric which can go up and down, such as prometheus.Gauge.
+type Gauge interface {
+	Set(float64)
+}
+
+// Histogram records observations, such as prometheus.Histogram.
+type Histogram interface {
+	Observe(float64)
+}
+
+// Metrics are the metrics of one Observable. Any of them may be nil.
+type Metrics struct {
+	// Emitted counts the items delivered to the Observer, errors excluded.
+	Emitted Counter
+	// Errors counts the errors delivered to the Observer.
+	Errors Counter
+	// Dropped counts the items dropped by a BackpressureStrategy, see
+	// OnDropped.
+	Dropped Counter
+	// QueueDepth is set to the number of items buffered in the Observable
+	// each time one is delivered to the Observer.
+	QueueDepth Gauge
+	// Latency records in seconds how long the Observer's OnNext takes for
+	// each item, or for each batch of a batched Observable.
+	Latency Histogram
+}
+
+// Instrument reports to m the items and errors which Subscribe delivers
+// from o, and returns o. Items read from o in any other way, for instance
+// by an operator or ToSlice, aren't reported, so instrument the Observable
+// which is subscribed to.
+func Instrument(o observable.Observable, m Metrics) observable.Observable {
+	return observable.OnSubscribeTo(o, func(ob observer.Observer) observer.Observer {
+		received := func(n int) {
+			if m.QueueDepth != nil {
+				m.QueueDepth.Set(float64(len(o)))
+			}
+			if m.Emitted != nil {
+				for i := 0; i < n; i++ {
+					m.Emitted.Inc()
+				}
+			}
+		}
+		timed := func(start time.Time) {
+			if m.Latency != nil {
+				m.Latency.Observe(time.Since(start).Seconds())
+			}
+		}
+
+		onNext := ob.NextHandler
+		ob.NextHandler = func(item interface{}) {
+			received(1)
+			defer timed(time.Now())
+			if onNext != nil {
+				onNext(item)
+			}
+		}
+		if onBatch := ob.BatchHandler; onBatch != nil {
+			ob.BatchHandler = func(items []interface{}) {
+				received(len(items))
+				defer timed(time.Now())
+				onBatch(items)
+			}
+		}
+		onError := ob.ErrHandler
+		ob.ErrHandler = func(err error) {
+			if m.QueueDepth != nil {
+				m.QueueDepth.Set(float64(len(o)))
+			}
+			if m.Errors != nil {
+				m.Errors.Inc()
+			}
+			if onError != nil {
+				onError(err)
+			}
+		}
+		return ob
+	})
+}
+
+// OnDropped returns a DropFunc counting dropped items in m.Dropped, to be
+// given to BackpressureStrategy.OnDropped.
+func (m Metrics) OnDropped() observable.DropFunc {
+	return func(interface{}) {
+		if m.Dropped != nil {
+			m.Dropped.Inc()
+		}
+	}
+}
+
+// LatencyRecorder returns an observable.LatencyRecorder recording latencies
+// in seconds in h, to be given to MeasureLatency with LatencyTo. Use one
+// Histogram per stage, for instance from a HistogramVec labelled by stage.
+func LatencyRecorder(h Histogram) observable.LatencyRecorder {
+	return func(stage string, latency time.Duration) {
+		h.Observe(latency.Seconds())
+	}
+}
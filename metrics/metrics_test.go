@@ -0,0 +1,121 @@
+package metrics
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/observer"
+	"github.com/stretchr/testify/assert"
+)
+
+type counter struct {
+	mu    sync.Mutex
+	count int
+}
+
+func (c *counter) Inc() {
+	c.mu.Lock()
+	c.count++
+	c.mu.Unlock()
+}
+
+func (c *counter) value() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.count
+}
+
+type recorder struct {
+	mu     sync.Mutex
+	values []float64
+}
+
+func (r *recorder) Set(v float64) {
+	r.Observe(v)
+}
+
+func (r *recorder) Observe(v float64) {
+	r.mu.Lock()
+	r.values = append(r.values, v)
+	r.mu.Unlock()
+}
+
+func TestInstrument(t *testing.T) {
+	emitted, errs := new(counter), new(counter)
+	depth, latency := new(recorder), new(recorder)
+	o := Instrument(observable.Just(1, 2, errors.New("bang")), Metrics{
+		Emitted:    emitted,
+		Errors:     errs,
+		QueueDepth: depth,
+		Latency:    latency,
+	})
+
+	var items []interface{}
+	var err error
+	<-o.Subscribe(observer.Observer{
+		NextHandler: func(item interface{}) {
+			time.Sleep(10 * time.Millisecond)
+			items = append(items, item)
+		},
+		ErrHandler: func(e error) { err = e },
+	})
+	assert.Equal(t, []interface{}{1, 2}, items)
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, 2, emitted.value())
+	assert.Equal(t, 1, errs.value())
+	assert.Equal(t, []float64{2, 1, 0}, depth.values)
+	if assert.Len(t, latency.values, 2) {
+		assert.True(t, latency.values[0] >= 0.01)
+	}
+}
+
+func TestInstrumentBatch(t *testing.T) {
+	emitted, latency := new(counter), new(recorder)
+	o := Instrument(observable.Just(observable.Batch{1, 2, 3}), Metrics{
+		Emitted: emitted,
+		Latency: latency,
+	})
+
+	var batches [][]interface{}
+	<-o.Subscribe(handlers.BatchFunc(func(items []interface{}) {
+		batches = append(batches, items)
+	}))
+	assert.Equal(t, [][]interface{}{{1, 2, 3}}, batches)
+	assert.Equal(t, 3, emitted.value())
+	assert.Len(t, latency.values, 1)
+}
+
+func TestInstrumentNilMetrics(t *testing.T) {
+	o := Instrument(observable.Just(1), Metrics{})
+	var items []interface{}
+	<-o.Subscribe(observer.Observer{})
+	<-Instrument(observable.Just(2), Metrics{}).SubscribeFunc(func(item interface{}) {
+		items = append(items, item)
+	})
+	assert.Equal(t, []interface{}{2}, items)
+}
+
+func TestOnDropped(t *testing.T) {
+	dropped := new(counter)
+	m := Metrics{Dropped: dropped}
+	ch := make(chan interface{}, 3)
+	for i := 0; i < 3; i++ {
+		ch <- i
+	}
+	close(ch)
+
+	o := observable.FromEventSource(ch, observable.DropLatest.OnDropped(m.OnDropped()))
+	items, _ := o.ToSlice()
+	assert.Equal(t, 3, len(items)+dropped.value())
+	Metrics{}.OnDropped()(nil)
+}
+
+func TestLatencyRecorder(t *testing.T) {
+	latency := new(recorder)
+	LatencyRecorder(latency)("stage", 1500*time.Millisecond)
+	assert.Equal(t, []float64{1.5}, latency.values)
+}
@@ -0,0 +1,102 @@
+package observable
+
+import (
+	"sync"
+	"time"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// AIMD is a concurrency limit controller. Each observed latency at or under
+// the target raises the limit by one (additive increase), and each latency
+// above it halves the limit (multiplicative decrease), within [Min, Max].
+type AIMD struct {
+	Min    uint
+	Max    uint
+	Target time.Duration
+
+	mu    sync.Mutex
+	limit uint
+}
+
+// NewAIMD creates an AIMD controller starting at the minimum limit.
+func NewAIMD(min, max uint, target time.Duration) *AIMD {
+	if min == 0 {
+		min = 1
+	}
+	if max < min {
+		max = min
+	}
+	return &AIMD{Min: min, Max: max, Target: target, limit: min}
+}
+
+// Limit returns the current concurrency limit.
+func (c *AIMD) Limit() uint {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.limit
+}
+
+// Observe adjusts the limit according to the latency of one operation.
+func (c *AIMD) Observe(latency time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if latency <= c.Target {
+		if c.limit < c.Max {
+			c.limit++
+		}
+		return
+	}
+	c.limit /= 2
+	if c.limit < c.Min {
+		c.limit = c.Min
+	}
+}
+
+// MapAdaptive maps a MappableFunc predicate to each item in the original
+// Observable like Map, but runs up to controller.Limit() applications at once.
+// The latency of every application is fed back to the controller, so the
+// concurrency tunes itself to what the work can sustain. Items are emitted
+// in their original order.
+func (o Observable) MapAdaptive(apply fx.MappableFunc, controller *AIMD) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	pending := make(chan chan interface{}, int(controller.Max))
+
+	var mu sync.Mutex
+	cond := sync.NewCond(&mu)
+	inflight := uint(0)
+
+	go func() {
+		for item := range o {
+			mu.Lock()
+			for inflight >= controller.Limit() {
+				cond.Wait()
+			}
+			inflight++
+			mu.Unlock()
+
+			result := make(chan interface{}, 1)
+			pending <- result
+			go func(item interface{}) {
+				start := time.Now()
+				result <- apply(item)
+				controller.Observe(time.Since(start))
+
+				mu.Lock()
+				inflight--
+				cond.Broadcast()
+				mu.Unlock()
+			}(item)
+		}
+		close(pending)
+	}()
+
+	go func() {
+		for result := range pending {
+			out <- <-result
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "MapAdaptive", nil, o)
+}
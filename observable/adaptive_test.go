@@ -0,0 +1,68 @@
+package observable
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAIMD(t *testing.T) {
+	c := NewAIMD(1, 4, 10*time.Millisecond)
+	assert.EqualValues(t, 1, c.Limit())
+
+	for i := 0; i < 5; i++ {
+		c.Observe(time.Millisecond)
+	}
+	assert.EqualValues(t, 4, c.Limit())
+
+	c.Observe(time.Second)
+	assert.EqualValues(t, 2, c.Limit())
+
+	c.Observe(time.Second)
+	c.Observe(time.Second)
+	assert.EqualValues(t, 1, c.Limit())
+}
+
+func TestObservableMapAdaptive(t *testing.T) {
+	var mu sync.Mutex
+	running, peak := 0, 0
+
+	double := func(item interface{}) interface{} {
+		mu.Lock()
+		running++
+		if running > peak {
+			peak = running
+		}
+		mu.Unlock()
+
+		<-time.After(time.Millisecond)
+
+		mu.Lock()
+		running--
+		mu.Unlock()
+		return item.(int) * 2
+	}
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	controller := NewAIMD(1, 4, time.Second)
+	sub := Range(0, 20).MapAdaptive(double, controller).Subscribe(onNext)
+	<-sub
+
+	expected := []int{}
+	for i := 0; i < 20; i++ {
+		expected = append(expected, i*2)
+	}
+	assert.Exactly(t, expected, nums)
+	assert.True(t, peak > 1 && peak <= 4)
+	assert.EqualValues(t, 4, controller.Limit())
+}
@@ -0,0 +1,198 @@
+package observable
+
+import (
+	"sync/atomic"
+
+	"github.com/reactivex/rxgo/errors"
+)
+
+// OverflowStrategy decides what happens to an item emitted while the buffer
+// between a producer and its subscriber is full.
+type OverflowStrategy uint32
+
+const (
+	// OverflowBlock blocks the producer until the subscriber catches up.
+	OverflowBlock OverflowStrategy = iota
+
+	// OverflowDropOldest evicts the oldest buffered item to make room.
+	OverflowDropOldest
+
+	// OverflowDropLatest discards the item being emitted.
+	OverflowDropLatest
+
+	// OverflowError emits a BackpressureError and terminates the stream.
+	OverflowError
+)
+
+// BackpressureStrategy pairs the capacity of the buffer between a producer
+// and its subscriber with the OverflowStrategy applied when it is full.
+type BackpressureStrategy struct {
+	Capacity uint
+	Overflow OverflowStrategy
+
+	onDropped  DropFunc
+	counter    *DropCounter
+	deadLetter *DeadLetterSink
+}
+
+// DropFunc is called with every item a BackpressureStrategy drops.
+type DropFunc func(item interface{})
+
+// DropCounter counts the items dropped by a BackpressureStrategy. It is safe
+// for concurrent use and can be shared between several strategies.
+type DropCounter struct {
+	dropped uint64
+}
+
+// Dropped returns the number of items dropped so far.
+func (c *DropCounter) Dropped() uint64 {
+	return atomic.LoadUint64(&c.dropped)
+}
+
+// OnDropped returns a copy of the strategy which calls f with each item it
+// drops, including the item which triggers an OverflowError.
+func (s BackpressureStrategy) OnDropped(f DropFunc) BackpressureStrategy {
+	s.onDropped = f
+	return s
+}
+
+// Counting returns a copy of the strategy which increments c for each item it
+// drops.
+func (s BackpressureStrategy) Counting(c *DropCounter) BackpressureStrategy {
+	s.counter = c
+	return s
+}
+
+var (
+	// Block hands each item directly to the subscriber and waits for it.
+	Block = BackpressureStrategy{Overflow: OverflowBlock}
+
+	// DropOldest keeps only the most recent item while the subscriber is busy.
+	DropOldest = BackpressureStrategy{Capacity: 1, Overflow: OverflowDropOldest}
+
+	// DropLatest keeps the first pending item and drops the ones after it
+	// while the subscriber is busy.
+	DropLatest = BackpressureStrategy{Capacity: 1, Overflow: OverflowDropLatest}
+
+	// Error terminates the stream as soon as an item can't be buffered.
+	Error = BackpressureStrategy{Capacity: 1, Overflow: OverflowError}
+)
+
+// Buffer buffers up to n items for a slow subscriber, then blocks.
+func Buffer(n uint) BackpressureStrategy {
+	return BackpressureStrategy{Capacity: n, Overflow: OverflowBlock}
+}
+
+// DeadLetter returns a copy of the strategy which sends each item it drops to
+// sink, along with the reason it was dropped.
+func (s BackpressureStrategy) DeadLetter(sink *DeadLetterSink) BackpressureStrategy {
+	s.deadLetter = sink
+	return s
+}
+
+// emitter sends items on a channel according to a BackpressureStrategy.
+type emitter struct {
+	out      chan interface{}
+	strategy BackpressureStrategy
+}
+
+func newEmitter(strategy BackpressureStrategy) emitter {
+	return emitter{
+		out:      make(chan interface{}, int(strategy.Capacity)),
+		strategy: strategy,
+	}
+}
+
+// emit sends an item on the emitter's channel and reports whether the
+// producer may carry on emitting.
+func (e emitter) emit(item interface{}) bool {
+	switch e.strategy.Overflow {
+	case OverflowDropOldest:
+		if cap(e.out) == 0 {
+			select {
+			case e.out <- item:
+			default:
+				e.drop(item, "dropped latest item")
+			}
+			return true
+		}
+		for {
+			select {
+			case e.out <- item:
+				return true
+			default:
+			}
+			select {
+			case oldest := <-e.out:
+				e.drop(oldest, "dropped oldest item")
+			default:
+			}
+		}
+	case OverflowDropLatest:
+		select {
+		case e.out <- item:
+		default:
+			e.drop(item, "dropped latest item")
+		}
+	case OverflowError:
+		select {
+		case e.out <- item:
+		default:
+			e.drop(item, "buffer overflow")
+			e.out <- errors.New(errors.BackpressureError)
+			return false
+		}
+	default:
+		e.out <- item
+	}
+	return true
+}
+
+// drop records an item discarded by the emitter's strategy.
+func (e emitter) drop(item interface{}, reason string) {
+	if e.strategy.counter != nil {
+		atomic.AddUint64(&e.strategy.counter.dropped, 1)
+	}
+	if e.strategy.onDropped != nil {
+		e.strategy.onDropped(item)
+	}
+	if e.strategy.deadLetter != nil {
+		e.strategy.deadLetter.Send(item, errors.New(errors.BackpressureError, reason))
+	}
+}
+
+// OnBackpressure decouples the original Observable from its subscriber with
+// the buffer described by strategy, and returns a new Observable which applies
+// the strategy whenever the subscriber falls behind. Errors are never dropped.
+func (o Observable) OnBackpressure(strategy BackpressureStrategy) Observable {
+	e := newEmitter(strategy)
+	register(e.out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				e.out <- item
+				continue
+			}
+			if !e.emit(item) {
+				break
+			}
+		}
+		closeStage(e.out)
+	}()
+	return describe(Observable(e.out), "OnBackpressure", map[string]interface{}{"capacity": strategy.Capacity, "overflow": strategy.Overflow}, o)
+}
+
+// FromEventSource creates a hot Observable from a channel of events. Events
+// are consumed from the channel as soon as they are sent, whether or not the
+// Observable has a subscriber yet, and those it can't deliver are buffered or
+// dropped according to strategy.
+func FromEventSource(ch chan interface{}, strategy BackpressureStrategy) Observable {
+	return created("FromEventSource", Observable(ch).OnBackpressure(strategy))
+}
+
+// OnBackpressureBuffer buffers up to capacity items between the original
+// Observable and its subscriber, applying overflow when the buffer is full.
+func (o Observable) OnBackpressureBuffer(capacity uint, overflow OverflowStrategy) Observable {
+	return describe(o.OnBackpressure(BackpressureStrategy{Capacity: capacity, Overflow: overflow}), "OnBackpressureBuffer",
+		map[string]interface{}{"capacity": capacity, "overflow": overflow}, o)
+}
@@ -0,0 +1,155 @@
+package observable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBufferStrategy(t *testing.T) {
+	strategy := Buffer(3)
+	assert.Equal(t, BackpressureStrategy{Capacity: 3, Overflow: OverflowBlock}, strategy)
+}
+
+func TestEmitterDropOldest(t *testing.T) {
+	e := newEmitter(BackpressureStrategy{Capacity: 2, Overflow: OverflowDropOldest})
+	for i := 0; i < 5; i++ {
+		assert.True(t, e.emit(i))
+	}
+
+	assert.Equal(t, 3, <-e.out)
+	assert.Equal(t, 4, <-e.out)
+}
+
+func TestEmitterDropLatest(t *testing.T) {
+	e := newEmitter(BackpressureStrategy{Capacity: 2, Overflow: OverflowDropLatest})
+	for i := 0; i < 5; i++ {
+		assert.True(t, e.emit(i))
+	}
+
+	assert.Equal(t, 0, <-e.out)
+	assert.Equal(t, 1, <-e.out)
+}
+
+func TestEmitterDropNotifications(t *testing.T) {
+	counter := new(DropCounter)
+	dropped := []interface{}{}
+	strategy := BackpressureStrategy{Capacity: 2, Overflow: OverflowDropOldest}.
+		OnDropped(func(item interface{}) {
+			dropped = append(dropped, item)
+		}).
+		Counting(counter)
+
+	e := newEmitter(strategy)
+	for i := 0; i < 5; i++ {
+		e.emit(i)
+	}
+
+	assert.EqualValues(t, 3, counter.Dropped())
+	assert.Exactly(t, []interface{}{0, 1, 2}, dropped)
+}
+
+func TestEmitterError(t *testing.T) {
+	e := newEmitter(Error)
+	assert.True(t, e.emit(0))
+
+	items := make(chan []interface{})
+	go func() {
+		<-time.After(10 * time.Millisecond)
+		items <- []interface{}{<-e.out, <-e.out}
+	}()
+
+	assert.False(t, e.emit(1))
+	received := <-items
+	assert.Equal(t, 0, received[0])
+	err, isErr := received[1].(errors.BaseError)
+	if assert.True(t, isErr) {
+		assert.Equal(t, int(errors.BackpressureError), err.Code())
+	}
+}
+
+func TestIntervalWithDropLatest(t *testing.T) {
+	fin := make(chan struct{})
+	myStream := Interval(fin, time.Millisecond, DropLatest)
+	nums := []int{}
+
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok && len(nums) < 3 {
+			nums = append(nums, num)
+			if len(nums) == 3 {
+				close(fin)
+			}
+			<-time.After(10 * time.Millisecond)
+		}
+	})
+
+	sub := myStream.Subscribe(onNext)
+	<-sub
+
+	assert.Len(t, nums, 3)
+	assert.True(t, nums[2]-nums[0] > 2)
+}
+
+func TestObservableOnBackpressureBuffer(t *testing.T) {
+	stream := Range(0, 10).OnBackpressureBuffer(3, OverflowDropLatest)
+	<-time.After(10 * time.Millisecond)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := stream.Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2}, nums)
+}
+
+func TestObservableOnBackpressureBufferBlock(t *testing.T) {
+	stream := Range(0, 10).OnBackpressureBuffer(3, OverflowBlock)
+	<-time.After(10 * time.Millisecond)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := stream.Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, nums)
+}
+
+func TestFromEventSource(t *testing.T) {
+	ch := make(chan interface{})
+	myStream := FromEventSource(ch, DropOldest)
+
+	// Nobody is subscribed yet, so only the latest event is kept.
+	for i := 0; i < 3; i++ {
+		ch <- i
+	}
+	<-time.After(10 * time.Millisecond)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+	sub := myStream.Subscribe(onNext)
+
+	<-time.After(10 * time.Millisecond)
+	ch <- 3
+	close(ch)
+	<-sub
+
+	assert.Exactly(t, []int{2, 3}, nums)
+}
@@ -0,0 +1,143 @@
+package observable
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// Batch is a group of items which Subscribe delivers to an Observer with a
+// single OnNextBatch call.
+type Batch []interface{}
+
+// Batched groups the items of the original Observable into Batches of at most
+// size items, amortizing the cost of a channel send over a whole Batch. A
+// Batch is emitted as soon as no more items are immediately available, so
+// batching never holds an item back, unless an optional maxLatency is given,
+// in which case a Batch waits up to maxLatency after its first item to fill
+// up. Errors are emitted on their own, after the Batch preceding them.
+//
+// Subscribe hands Batches to an Observer's NextHandler one item at a time,
+// so batching right before Subscribe is transparent to the Observer.
+func (o Observable) Batched(size uint, maxLatency ...time.Duration) Observable {
+	if size == 0 {
+		size = 1
+	}
+	var latency time.Duration
+	if len(maxLatency) > 0 {
+		latency = maxLatency[0]
+	}
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+
+			batch := Batch{item}
+			var failure interface{}
+			var timer *time.Timer
+			var deadline <-chan time.Time
+			if latency > 0 {
+				timer = time.NewTimer(latency)
+				deadline = timer.C
+			}
+		DrainLoop:
+			for uint(len(batch)) < size {
+				var item interface{}
+				var ok bool
+				select {
+				case item, ok = <-o:
+				default:
+					if deadline == nil {
+						break DrainLoop
+					}
+					select {
+					case item, ok = <-o:
+					case <-deadline:
+						break DrainLoop
+					}
+				}
+				if !ok {
+					break DrainLoop
+				}
+				if _, isErr := item.(error); isErr {
+					failure = item
+					break DrainLoop
+				}
+				batch = append(batch, item)
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+
+			out <- batch
+			if failure != nil {
+				out <- failure
+			}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Batched", map[string]interface{}{"size": size}, o)
+}
+
+// Chunk groups the items of the original Observable into chunks, emitted as
+// []interface{}, whose total size, as measured by size, doesn't exceed max,
+// for building batches bounded in bytes rather than in items. An item larger
+// than max is emitted in a chunk of its own. A chunk is emitted when the next
+// item wouldn't fit, when the original Observable terminates, or after an
+// optional timeout since its first item, so that a slow stream doesn't hold
+// items back indefinitely. Unlike a Batch, a chunk reaches an Observer's
+// NextHandler whole.
+func (o Observable) Chunk(size fx.SizeFunc, max uint, timeout ...time.Duration) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		defer closeStage(out)
+		var chunk []interface{}
+		var total uint
+		var timer *time.Timer
+		var expired <-chan time.Time
+		flush := func() {
+			if timer != nil {
+				timer.Stop()
+				expired = nil
+			}
+			if len(chunk) > 0 {
+				out <- chunk
+				chunk, total = nil, 0
+			}
+		}
+
+		for {
+			select {
+			case item, ok := <-o:
+				if !ok {
+					flush()
+					return
+				}
+				if _, isErr := item.(error); isErr {
+					flush()
+					out <- item
+					continue
+				}
+				n := size(item)
+				if len(chunk) > 0 && total+n > max {
+					flush()
+				}
+				if len(chunk) == 0 && len(timeout) > 0 {
+					timer = time.NewTimer(timeout[0])
+					expired = timer.C
+				}
+				chunk = append(chunk, item)
+				total += n
+			case <-expired:
+				expired = nil
+				flush()
+			}
+		}
+	}()
+	return describe(Observable(out), "Chunk", map[string]interface{}{"max": max}, o)
+}
@@ -0,0 +1,147 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableBatched(t *testing.T) {
+	source := make(chan interface{}, 10)
+	for i := 0; i < 7; i++ {
+		source <- i
+	}
+	close(source)
+
+	batches := [][]interface{}{}
+	onBatch := handlers.BatchFunc(func(items []interface{}) {
+		batches = append(batches, items)
+	})
+
+	sub := Observable(source).Batched(3).Subscribe(onBatch)
+	<-sub
+
+	expected := [][]interface{}{{0, 1, 2}, {3, 4, 5}, {6}}
+	assert.Exactly(t, expected, batches)
+}
+
+func TestObservableBatchedFallsBackToNextHandler(t *testing.T) {
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := Range(0, 5).Batched(2).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2, 3, 4}, nums)
+}
+
+func TestObservableBatchedWithError(t *testing.T) {
+	source := make(chan interface{}, 10)
+	source <- 1
+	source <- 2
+	source <- errors.New("bang")
+	source <- 3
+	close(source)
+
+	items := []interface{}{}
+	onBatch := handlers.BatchFunc(func(batch []interface{}) {
+		items = append(items, batch...)
+	})
+	errText := ""
+	onError := handlers.ErrFunc(func(err error) {
+		errText = err.Error()
+	})
+
+	sub := Observable(source).Batched(10).Subscribe(observer.New(onBatch, onError))
+	s := <-sub
+
+	assert.Exactly(t, []interface{}{1, 2}, items)
+	assert.Equal(t, "bang", errText)
+	assert.NotNil(t, s.Err())
+}
+
+func TestObservableChunk(t *testing.T) {
+	length := func(item interface{}) uint { return uint(len(item.(string))) }
+
+	items, err := Just("ab", "cde", "f", "ghijklm", "n", "o").Chunk(length, 6).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		[]interface{}{"ab", "cde", "f"},
+		[]interface{}{"ghijklm"},
+		[]interface{}{"n", "o"},
+	}, items)
+
+	items, err = Just("ab", errors.New("bang"), "cd").Chunk(length, 6).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{[]interface{}{"ab"}}, items)
+}
+
+func TestObservableChunkTimeout(t *testing.T) {
+	in := make(chan interface{})
+	chunks := FromChannel(in).Chunk(func(interface{}) uint { return 1 }, 10, 10*time.Millisecond)
+
+	in <- 1
+	in <- 2
+	select {
+	case chunk := <-chunks:
+		assert.Equal(t, []interface{}{1, 2}, chunk)
+	case <-time.After(time.Second):
+		assert.Fail(t, "chunk was not flushed")
+	}
+	close(in)
+	_, ok := <-chunks
+	assert.False(t, ok)
+}
+
+func TestObservableChunkSubscribe(t *testing.T) {
+	var chunks []interface{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		chunks = append(chunks, item)
+	})
+
+	<-Just(1, 2, 3).Chunk(func(interface{}) uint { return 1 }, 2).Subscribe(observer.New(onNext))
+	assert.Equal(t, []interface{}{[]interface{}{1, 2}, []interface{}{3}}, chunks)
+}
+
+func TestObservableBatchedWithMaxLatency(t *testing.T) {
+	in := make(chan interface{})
+	batches := FromChannel(in).Batched(3, 50*time.Millisecond)
+
+	go func() {
+		in <- 1
+		time.Sleep(5 * time.Millisecond)
+		in <- 2
+		time.Sleep(5 * time.Millisecond)
+		in <- 3
+		in <- 4
+		close(in)
+	}()
+
+	assert.Equal(t, Batch{1, 2, 3}, <-batches)
+	assert.Equal(t, Batch{4}, <-batches)
+	_, ok := <-batches
+	assert.False(t, ok)
+}
+
+func TestObservableBatchedMaxLatencyExpires(t *testing.T) {
+	in := make(chan interface{})
+	batches := FromChannel(in).Batched(10, 10*time.Millisecond)
+
+	in <- 1
+	select {
+	case batch := <-batches:
+		assert.Equal(t, Batch{1}, batch)
+	case <-time.After(time.Second):
+		assert.Fail(t, "batch was held back past its latency")
+	}
+	close(in)
+}
@@ -0,0 +1,61 @@
+package observable
+
+import (
+	"testing"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/iterable"
+)
+
+// BenchmarkSubscribe measures the cost of delivering items through a
+// Subscribe. Items travel as they are, without any per-emission wrapper,
+// so the only allocations per item are those of boxing it.
+func BenchmarkSubscribe(b *testing.B) {
+	b.ReportAllocs()
+	source := make(chan interface{})
+	go func() {
+		for i := 0; i < b.N; i++ {
+			source <- i
+		}
+		close(source)
+	}()
+	b.ResetTimer()
+	<-Observable(source).Subscribe(handlers.NextFunc(func(interface{}) {}))
+}
+
+func benchmarkItems(n int) []interface{} {
+	items := make([]interface{}, n)
+	for i := range items {
+		items[i] = i
+	}
+	return items
+}
+
+// BenchmarkFromIterable measures ingesting a slice through iterable.New,
+// which is backed by a channel.
+func BenchmarkFromIterable(b *testing.B) {
+	b.ReportAllocs()
+	it, _ := iterable.New(benchmarkItems(b.N))
+	b.ResetTimer()
+	for range From(it) {
+	}
+}
+
+// BenchmarkFromSliceIterator measures ingesting a slice through
+// iterable.NewSlice, which iterates in place.
+func BenchmarkFromSliceIterator(b *testing.B) {
+	b.ReportAllocs()
+	it := iterable.NewSlice(benchmarkItems(b.N))
+	b.ResetTimer()
+	for range From(it) {
+	}
+}
+
+// BenchmarkJustSingle measures creating and draining a single-item Just.
+func BenchmarkJustSingle(b *testing.B) {
+	b.ReportAllocs()
+	for i := 0; i < b.N; i++ {
+		for range Just(i) {
+		}
+	}
+}
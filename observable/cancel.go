@@ -0,0 +1,160 @@
+package observable
+
+import (
+	"sync"
+
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// stages maps the channel of every running source and operator to its
+// *stage, so that unsubscribing from an Observable can reach the stages it
+// is built from. A stage is forgotten when its channel is closed.
+var stages sync.Map
+
+// stage is the output of a source or operator. Cancelling it cancels the
+// stages of its inputs in turn, up to the sources, which stop producing and
+// complete. The operators in between keep reading until their input
+// completes, so that the whole pipeline winds down and its goroutines exit.
+type stage struct {
+	inputs []Observable
+	done   chan struct{}
+	once   sync.Once
+
+	mu    sync.Mutex
+	inner Observable
+
+	// onCancel, if set, replaces the cancellation of the inputs.
+	onCancel func()
+}
+
+// register records out as the output of a stage reading inputs, and
+// returns the stage. It must be called before out can be closed, and out
+// closed with closeStage.
+func register(out chan interface{}, inputs ...Observable) *stage {
+	s := &stage{inputs: inputs, done: make(chan struct{})}
+	stages.Store(Observable(out), s)
+	return s
+}
+
+// closeStage forgets the stage of out, closes it and releases the inputs of
+// the stage, so that an operator which completes before its inputs, such as
+// Take, unsubscribes from them.
+func closeStage(out chan interface{}) {
+	v, ok := stages.Load(Observable(out))
+	stages.Delete(Observable(out))
+	close(out)
+	if !ok {
+		return
+	}
+	s := v.(*stage)
+	s.mu.Lock()
+	inner := s.inner
+	s.mu.Unlock()
+	if inner != nil {
+		release(inner)
+	}
+	for _, in := range s.inputs {
+		release(in)
+	}
+}
+
+// stageOf returns the stage emitting on o, or nil if o isn't the output of
+// a running source or operator of this package.
+func stageOf(o Observable) *stage {
+	if s, ok := stages.Load(o); ok {
+		return s.(*stage)
+	}
+	return nil
+}
+
+// cancelled returns a channel closed once the stage is cancelled, or nil,
+// which blocks forever, for a nil stage.
+func (s *stage) cancelled() <-chan struct{} {
+	if s == nil {
+		return nil
+	}
+	return s.done
+}
+
+// isCancelled reports whether the stage was cancelled.
+func (s *stage) isCancelled() bool {
+	select {
+	case <-s.cancelled():
+		return true
+	default:
+		return false
+	}
+}
+
+// follow makes the stage read o, an input it only builds once running, for
+// instance the Observable of a factory. Cancelling the stage cancels the last
+// Observable it followed, straight away if it is already cancelled.
+func (s *stage) follow(o Observable) {
+	s.mu.Lock()
+	s.inner = o
+	s.mu.Unlock()
+	if s.isCancelled() {
+		cancel(o)
+	}
+}
+
+func (s *stage) cancel() {
+	s.once.Do(func() {
+		close(s.done)
+		s.mu.Lock()
+		inner := s.inner
+		s.mu.Unlock()
+		cancel(inner)
+
+		if s.onCancel != nil {
+			s.onCancel()
+			return
+		}
+		for _, in := range s.inputs {
+			cancel(in)
+		}
+	})
+}
+
+// cancel cancels the stage emitting on o, if any.
+func cancel(o Observable) {
+	if s := stageOf(o); s != nil {
+		s.cancel()
+	}
+}
+
+// unsubscribe cancels o and discards whatever it still emits, for a reader
+// which stops reading o before it completes. Without it, the stage emitting
+// on o would block forever on its next send.
+func unsubscribe(o Observable) {
+	cancel(o)
+	go func() {
+		for range o {
+		}
+	}()
+}
+
+// release unsubscribes from o unless it has already completed.
+func release(o Observable) {
+	select {
+	case _, ok := <-o:
+		if !ok {
+			return
+		}
+	default:
+	}
+	unsubscribe(o)
+}
+
+// Unsubscribe stops the Observable. It cancels the source or operator
+// emitting it and, through their inputs, every stage of the pipeline up to
+// the sources, which stop producing. A Subscribe in progress returns without
+// calling OnDone, and the items still in flight are discarded.
+//
+// Sources and operators of this package are cancelled this way. An
+// Observable built on a channel of its own, such as FromChannel, isn't
+// stopped, but is still read to its end so that its sender doesn't block.
+func (o Observable) Unsubscribe() subscription.Subscription {
+	unsubscribe(o)
+	return subscription.New().Unsubscribe()
+}
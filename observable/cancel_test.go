@@ -0,0 +1,97 @@
+package observable
+
+import (
+	"runtime"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/observer"
+	"github.com/stretchr/testify/assert"
+)
+
+// waitGoroutines waits for the number of goroutines to fall back to at most
+// n, failing the test if it doesn't within a second.
+func waitGoroutines(t *testing.T, n int) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for runtime.NumGoroutine() > n {
+		if time.Now().After(deadline) {
+			buf := make([]byte, 1<<16)
+			t.Fatalf("%d goroutines left, want at most %d:\n%s", runtime.NumGoroutine(), n, buf[:runtime.Stack(buf, true)])
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestUnsubscribeStopsPipeline(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	o := Interval(make(chan struct{}), time.Millisecond).
+		Map(func(item interface{}) interface{} { return item.(int) * 2 }).
+		Filter(func(item interface{}) bool { return item.(int)%4 == 0 })
+
+	assert.Equal(t, 0, <-o)
+	assert.Equal(t, 4, <-o)
+	o.Unsubscribe()
+	waitGoroutines(t, baseline)
+}
+
+func TestUnsubscribeSubscription(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	o := Interval(make(chan struct{}), time.Millisecond).Map(func(item interface{}) interface{} { return item })
+
+	var count, done int32
+	sub := o.Subscribe(observer.Observer{
+		NextHandler: func(interface{}) { atomic.AddInt32(&count, 1) },
+		DoneHandler: func() { atomic.AddInt32(&done, 1) },
+	})
+	for atomic.LoadInt32(&count) < 3 {
+		time.Sleep(time.Millisecond)
+	}
+	o.Unsubscribe()
+
+	select {
+	case s := <-sub:
+		assert.Nil(t, s.Err())
+	case <-time.After(time.Second):
+		t.Fatal("Subscribe didn't return after Unsubscribe")
+	}
+	assert.EqualValues(t, 0, atomic.LoadInt32(&done))
+	waitGoroutines(t, baseline)
+}
+
+func TestTakeUnsubscribesUpstream(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	items, err := Interval(make(chan struct{}), time.Millisecond).Take(3).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2}, items)
+	waitGoroutines(t, baseline)
+}
+
+func TestUnsubscribeRepeatFrom(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	o := RepeatFrom(func() Observable { return Range(0, 1000) }, 0)
+	assert.Equal(t, 0, <-o)
+	o.Unsubscribe()
+	waitGoroutines(t, baseline)
+}
+
+func TestUnsubscribeChannel(t *testing.T) {
+	ch := make(chan interface{})
+	o := FromChannel(ch).Map(func(item interface{}) interface{} { return item })
+	o.Unsubscribe()
+
+	// The channel isn't ours to stop, but its sender doesn't block.
+	sent := make(chan struct{})
+	go func() {
+		ch <- 1
+		ch <- 2
+		close(ch)
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("sender blocked after Unsubscribe")
+	}
+}
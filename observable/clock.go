@@ -0,0 +1,109 @@
+package observable
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock tells the time to time-aware operators, so that it can be controlled
+// in tests.
+type Clock interface {
+	Now() time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+// SystemClock is the Clock reading the system time, used by default.
+var SystemClock Clock = systemClock{}
+
+// ManualClock is a Clock which only moves when told to.
+type ManualClock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+// NewManualClock creates a ManualClock set to now.
+func NewManualClock(now time.Time) *ManualClock {
+	return &ManualClock{now: now}
+}
+
+// Now returns the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// Advance moves the clock forward by d.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.mu.Lock()
+	c.now = c.now.Add(d)
+	c.mu.Unlock()
+}
+
+func clockOrDefault(clock []Clock) Clock {
+	if len(clock) > 0 && clock[0] != nil {
+		return clock[0]
+	}
+	return SystemClock
+}
+
+// Timestamped is an item together with the time it was emitted.
+type Timestamped struct {
+	Value interface{}
+	Time  time.Time
+}
+
+// Elapsed is an item together with the time elapsed since the previous one.
+type Elapsed struct {
+	Value    interface{}
+	Interval time.Duration
+}
+
+// Timestamp wraps each item of the original Observable in a Timestamped
+// reading an optional Clock, which defaults to SystemClock. Errors are
+// passed through unwrapped.
+func (o Observable) Timestamp(clock ...Clock) Observable {
+	c := clockOrDefault(clock)
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+			out <- Timestamped{Value: item, Time: c.Now()}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Timestamp", nil, o)
+}
+
+// TimeInterval wraps each item of the original Observable in an Elapsed
+// holding the time since the previous item, or since TimeInterval was
+// called for the first one, reading an optional Clock, which defaults to
+// SystemClock. Errors are passed through unwrapped.
+func (o Observable) TimeInterval(clock ...Clock) Observable {
+	c := clockOrDefault(clock)
+	last := c.Now()
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+			now := c.Now()
+			out <- Elapsed{Value: item, Interval: now.Sub(last)}
+			last = now
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "TimeInterval", nil, o)
+}
@@ -0,0 +1,52 @@
+package observable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualClock(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := NewManualClock(start)
+	assert.Equal(t, start, clock.Now())
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), clock.Now())
+}
+
+func TestObservableTimestamp(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := NewManualClock(start)
+	in := make(chan interface{})
+	stamped := FromChannel(in).Timestamp(clock)
+
+	go func() {
+		in <- "a"
+		close(in)
+	}()
+	assert.Equal(t, Timestamped{Value: "a", Time: start}, <-stamped)
+
+	before := time.Now()
+	item, err := Just(1).Timestamp().BlockingSingle()
+	assert.Nil(t, err)
+	assert.False(t, item.(Timestamped).Time.Before(before))
+}
+
+func TestObservableTimeInterval(t *testing.T) {
+	clock := NewManualClock(time.Unix(100, 0))
+	in := make(chan interface{})
+	intervals := FromChannel(in).TimeInterval(clock)
+
+	clock.Advance(time.Second)
+	go func() { in <- "a" }()
+	assert.Equal(t, Elapsed{Value: "a", Interval: time.Second}, <-intervals)
+
+	clock.Advance(3 * time.Second)
+	go func() { in <- "b" }()
+	assert.Equal(t, Elapsed{Value: "b", Interval: 3 * time.Second}, <-intervals)
+
+	close(in)
+	_, ok := <-intervals
+	assert.False(t, ok)
+}
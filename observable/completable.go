@@ -0,0 +1,104 @@
+package observable
+
+import (
+	"sync"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Completable is an operation which only completes or fails, such as a
+// write. Like a Deferred, it does nothing until it is subscribed to, and
+// runs again on every subscription.
+type Completable struct {
+	run func() error
+}
+
+// CompletableFrom creates a Completable running f.
+func CompletableFrom(f func() error) Completable {
+	return Completable{run: f}
+}
+
+// Completed creates a Completable which completes straight away.
+func Completed() Completable {
+	return CompletableFrom(func() error { return nil })
+}
+
+// CompletableError creates a Completable failing with err.
+func CompletableError(err error) Completable {
+	return CompletableFrom(func() error { return err })
+}
+
+// Completable converts the original Observable into a Completable which
+// discards its items and fails with its error, if any. As the Observable is
+// already running, the Completable may only be subscribed to once.
+func (o Observable) Completable() Completable {
+	return CompletableFrom(func() error {
+		_, err := o.IgnoreElements().ToSlice()
+		return err
+	})
+}
+
+// Await runs the Completable and returns its error, if any.
+func (c Completable) Await() error {
+	return c.run()
+}
+
+// Observable runs the Completable in its own goroutine and returns an
+// Observable emitting nothing but its error, if any.
+func (c Completable) Observable() Observable {
+	out := make(chan interface{}, 1)
+	go func() {
+		if err := c.run(); err != nil {
+			out <- err
+		}
+		close(out)
+	}()
+	return Observable(out)
+}
+
+// Subscribe runs the Completable and calls onDone on completion or onError
+// on failure. Either of them may be nil.
+func (c Completable) Subscribe(onDone handlers.DoneFunc, onError handlers.ErrFunc) <-chan subscription.Subscription {
+	return c.Observable().SubscribeAll(nil, onError, onDone)
+}
+
+// AndThen returns a Completable running next once c has completed, unless c
+// failed.
+func (c Completable) AndThen(next Completable) Completable {
+	return ConcatCompletable(c, next)
+}
+
+// ConcatCompletable returns a Completable running cs one after the other,
+// stopping at the first failure.
+func ConcatCompletable(cs ...Completable) Completable {
+	return CompletableFrom(func() error {
+		for _, c := range cs {
+			if err := c.run(); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// MergeCompletable returns a Completable running cs concurrently, which
+// completes once they all have and fails with the first error among them.
+func MergeCompletable(cs ...Completable) Completable {
+	return CompletableFrom(func() error {
+		var wg sync.WaitGroup
+		var once sync.Once
+		var first error
+		for _, c := range cs {
+			wg.Add(1)
+			go func(c Completable) {
+				defer wg.Done()
+				if err := c.run(); err != nil {
+					once.Do(func() { first = err })
+				}
+			}(c)
+		}
+		wg.Wait()
+		return first
+	})
+}
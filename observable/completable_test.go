@@ -0,0 +1,68 @@
+package observable
+
+import (
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompletable(t *testing.T) {
+	runs := 0
+	c := CompletableFrom(func() error {
+		runs++
+		return nil
+	})
+	assert.Equal(t, 0, runs)
+	assert.Nil(t, c.Await())
+	assert.Nil(t, c.Await())
+	assert.Equal(t, 2, runs)
+
+	assert.EqualError(t, CompletableError(errors.New("bang")).Await(), "bang")
+	assert.Nil(t, Completed().Await())
+}
+
+func TestCompletableObservableAndSubscribe(t *testing.T) {
+	items, err := CompletableError(errors.New("bang")).Observable().ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Empty(t, items)
+
+	done := false
+	sub := <-Completed().Subscribe(func() { done = true }, nil)
+	assert.Nil(t, sub.Err())
+	assert.True(t, done)
+}
+
+func TestObservableToCompletable(t *testing.T) {
+	assert.Nil(t, Just(1, 2).Completable().Await())
+	assert.EqualError(t, Just(1, errors.New("bang")).Completable().Await(), "bang")
+}
+
+func TestCompletableCombinators(t *testing.T) {
+	var order []int
+	step := func(i int) Completable {
+		return CompletableFrom(func() error {
+			order = append(order, i)
+			return nil
+		})
+	}
+	assert.Nil(t, step(1).AndThen(step(2)).AndThen(step(3)).Await())
+	assert.Equal(t, []int{1, 2, 3}, order)
+
+	order = nil
+	err := ConcatCompletable(step(1), CompletableError(errors.New("bang")), step(3)).Await()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []int{1}, order)
+
+	var count int32
+	inc := CompletableFrom(func() error {
+		atomic.AddInt32(&count, 1)
+		return nil
+	})
+	assert.Nil(t, MergeCompletable(inc, inc, inc).Await())
+	assert.Equal(t, int32(3), count)
+
+	err = MergeCompletable(inc, CompletableError(errors.New("bang"))).Await()
+	assert.EqualError(t, err, "bang")
+}
@@ -0,0 +1,94 @@
+package observable
+
+import "sync"
+
+// Emitter pushes items into an Observable built with Create. It is safe for
+// concurrent use, so that callbacks running on several goroutines can emit
+// items, in no particular order, and a producer blocked in Next because its
+// subscriber is slow can be stopped by Complete from another goroutine.
+type Emitter interface {
+	// Next emits an item.
+	Next(item interface{})
+
+	// Error emits an error and terminates the Observable.
+	Error(err error)
+
+	// Complete terminates the Observable.
+	Complete()
+
+	// IsDisposed reports whether the Observable has terminated or was
+	// unsubscribed from, in which case the producer should stop and any
+	// further emission is ignored.
+	IsDisposed() bool
+}
+
+type createEmitter struct {
+	// sending is read-locked while an item is sent, so that out is only
+	// closed once no send is in progress.
+	sending  sync.RWMutex
+	out      chan interface{}
+	once     sync.Once
+	disposed chan struct{}
+}
+
+// send sends item unless the emitter is or gets disposed, and reports
+// whether it did.
+func (e *createEmitter) send(item interface{}) bool {
+	e.sending.RLock()
+	defer e.sending.RUnlock()
+	select {
+	case <-e.disposed:
+		return false
+	default:
+	}
+	select {
+	case e.out <- item:
+		return true
+	case <-e.disposed:
+		return false
+	}
+}
+
+func (e *createEmitter) Next(item interface{}) {
+	e.send(item)
+}
+
+func (e *createEmitter) Error(err error) {
+	if e.send(err) {
+		e.dispose()
+	}
+}
+
+func (e *createEmitter) Complete() {
+	e.dispose()
+}
+
+func (e *createEmitter) IsDisposed() bool {
+	select {
+	case <-e.disposed:
+		return true
+	default:
+		return false
+	}
+}
+
+func (e *createEmitter) dispose() {
+	e.once.Do(func() {
+		close(e.disposed)
+		e.sending.Lock()
+		closeStage(e.out)
+		e.sending.Unlock()
+	})
+}
+
+// Create creates an Observable from a function which pushes items through an
+// Emitter, the general way of wrapping callback-based APIs. The function runs
+// on its own goroutine, and the Observable terminates once the function calls
+// Error or Complete, or once it is unsubscribed from. Either way the Emitter
+// is disposed, which the function can watch for with IsDisposed.
+func Create(f func(Emitter)) Observable {
+	e := &createEmitter{out: make(chan interface{}), disposed: make(chan struct{})}
+	register(e.out).onCancel = e.dispose
+	go f(e)
+	return created("Create", Observable(e.out))
+}
@@ -0,0 +1,106 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateOperator(t *testing.T) {
+	disposed := make(chan bool, 1)
+	myStream := Create(func(e Emitter) {
+		e.Next(1)
+		e.Next(2)
+		e.Complete()
+		e.Next(3)
+		disposed <- e.IsDisposed()
+	})
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+	done := false
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+
+	sub := myStream.Subscribe(observer.New(onNext, onDone))
+	<-sub
+
+	assert.Exactly(t, []int{1, 2}, nums)
+	assert.True(t, done)
+	assert.True(t, <-disposed)
+}
+
+func TestCreateOperatorWithError(t *testing.T) {
+	myStream := Create(func(e Emitter) {
+		for i := 0; !e.IsDisposed(); i++ {
+			if i == 2 {
+				e.Error(errors.New("bang"))
+				continue
+			}
+			e.Next(i)
+		}
+	})
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+	onError := handlers.ErrFunc(func(err error) {})
+
+	sub := myStream.Subscribe(observer.New(onNext, onError))
+	s := <-sub
+
+	assert.Exactly(t, []int{0, 1}, nums)
+	assert.EqualError(t, s.Err(), "bang")
+}
+
+func TestCreateOperatorDisposeWhileBlocked(t *testing.T) {
+	emitter := make(chan Emitter)
+	stopped := make(chan struct{})
+	myStream := Create(func(e Emitter) {
+		emitter <- e
+		for i := 0; !e.IsDisposed(); i++ {
+			e.Next(i)
+		}
+		close(stopped)
+	})
+
+	e := <-emitter
+	assert.Equal(t, 0, <-myStream)
+	// The producer is now blocked in Next, as nothing reads the stream.
+	e.Complete()
+	<-stopped
+	_, ok := <-myStream
+	assert.False(t, ok)
+}
+
+func TestCreateOperatorUnsubscribe(t *testing.T) {
+	stopped := make(chan struct{})
+	myStream := Create(func(e Emitter) {
+		for i := 0; !e.IsDisposed(); i++ {
+			e.Next(i)
+		}
+		close(stopped)
+	}).Map(func(item interface{}) interface{} { return item })
+
+	assert.Equal(t, 0, <-myStream)
+	assert.Equal(t, 1, <-myStream)
+	myStream.Unsubscribe()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("the producer wasn't told the Observable was unsubscribed from")
+	}
+}
@@ -0,0 +1,52 @@
+package observable
+
+import "sync/atomic"
+
+// DeadLetter is an item which was dropped or rejected somewhere in a
+// pipeline, together with the reason why.
+type DeadLetter struct {
+	Item   interface{}
+	Reason error
+}
+
+// DeadLetterSink collects DeadLetters from any number of pipelines and
+// exposes them as an Observable, giving an audit trail of lost items.
+type DeadLetterSink struct {
+	letters    chan interface{}
+	overflowed uint64
+}
+
+// NewDeadLetterSink creates a DeadLetterSink buffering up to buffer
+// DeadLetters. Once the buffer is full, further DeadLetters are discarded
+// and counted by Overflowed, so that a slow audit trail never blocks the
+// pipelines dropping items.
+func NewDeadLetterSink(buffer uint) *DeadLetterSink {
+	return &DeadLetterSink{letters: make(chan interface{}, int(buffer))}
+}
+
+// Send records an item together with the reason it was lost, unless the
+// sink's buffer is full.
+func (s *DeadLetterSink) Send(item interface{}, reason error) {
+	select {
+	case s.letters <- DeadLetter{Item: item, Reason: reason}:
+	default:
+		atomic.AddUint64(&s.overflowed, 1)
+	}
+}
+
+// Overflowed returns the number of DeadLetters discarded because the sink's
+// buffer was full.
+func (s *DeadLetterSink) Overflowed() uint64 {
+	return atomic.LoadUint64(&s.overflowed)
+}
+
+// Observable returns an Observable emitting every DeadLetter sent to the sink.
+func (s *DeadLetterSink) Observable() Observable {
+	return Observable(s.letters)
+}
+
+// Close completes the sink's Observable. No more DeadLetters may be sent
+// after Close.
+func (s *DeadLetterSink) Close() {
+	close(s.letters)
+}
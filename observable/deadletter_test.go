@@ -0,0 +1,63 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeadLetterSink(t *testing.T) {
+	sink := NewDeadLetterSink(2)
+	bang := errors.New("bang")
+	sink.Send(1, bang)
+	sink.Send(2, bang)
+	sink.Close()
+
+	letters := []DeadLetter{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if letter, ok := item.(DeadLetter); ok {
+			letters = append(letters, letter)
+		}
+	})
+
+	sub := sink.Observable().Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []DeadLetter{{1, bang}, {2, bang}}, letters)
+}
+
+func TestDeadLetterSinkOverflow(t *testing.T) {
+	sink := NewDeadLetterSink(1)
+	bang := errors.New("bang")
+	sink.Send(1, bang)
+	sink.Send(2, bang)
+	sink.Send(3, bang)
+	sink.Close()
+
+	letters, err := sink.Observable().ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{DeadLetter{1, bang}}, letters)
+	assert.Equal(t, uint64(2), sink.Overflowed())
+}
+
+func TestEmitterDeadLetter(t *testing.T) {
+	sink := NewDeadLetterSink(10)
+	strategy := BackpressureStrategy{Capacity: 1, Overflow: OverflowDropLatest}.DeadLetter(sink)
+
+	e := newEmitter(strategy)
+	for i := 0; i < 3; i++ {
+		e.emit(i)
+	}
+	sink.Close()
+
+	items := []interface{}{}
+	for letter := range sink.Observable() {
+		items = append(items, letter.(DeadLetter).Item)
+		assert.Error(t, letter.(DeadLetter).Reason)
+	}
+
+	assert.Exactly(t, []interface{}{1, 2}, items)
+}
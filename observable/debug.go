@@ -0,0 +1,158 @@
+package observable
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"runtime"
+	"strconv"
+	"sync/atomic"
+	"time"
+)
+
+// DebugSignal is the kind of signal reported by Debug.
+type DebugSignal uint32
+
+const (
+	// DebugSubscribe is reported when Debug starts consuming its source.
+	DebugSubscribe DebugSignal = iota
+	// DebugNext is reported for each item.
+	DebugNext
+	// DebugError is reported for an error.
+	DebugError
+	// DebugComplete is reported when the source completes.
+	DebugComplete
+)
+
+func (s DebugSignal) String() string {
+	switch s {
+	case DebugSubscribe:
+		return "subscribe"
+	case DebugNext:
+		return "next"
+	case DebugError:
+		return "error"
+	case DebugComplete:
+		return "complete"
+	default:
+		return "DebugSignal(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// DebugEvent is a signal observed by Debug.
+type DebugEvent struct {
+	Label     string
+	Signal    DebugSignal
+	Item      interface{}
+	Time      time.Time
+	Goroutine uint64
+}
+
+// DebugOption configures Debug.
+type DebugOption func(*debugConfig)
+
+type debugConfig struct {
+	writer io.Writer
+	format func(DebugEvent) string
+	clock  Clock
+}
+
+// DebugWriter makes Debug write to w instead of os.Stderr.
+func DebugWriter(w io.Writer) DebugOption {
+	return func(c *debugConfig) {
+		c.writer = w
+	}
+}
+
+// DebugFormat makes Debug format each DebugEvent with format, which should
+// return a full line.
+func DebugFormat(format func(DebugEvent) string) DebugOption {
+	return func(c *debugConfig) {
+		c.format = format
+	}
+}
+
+// DebugClock makes Debug timestamp events with clock.
+func DebugClock(clock Clock) DebugOption {
+	return func(c *debugConfig) {
+		c.clock = clock
+	}
+}
+
+func formatDebugEvent(e DebugEvent) string {
+	line := fmt.Sprintf("%s [%s] goroutine %d: %s", e.Time.Format(time.RFC3339Nano), e.Label, e.Goroutine, e.Signal)
+	if e.Signal == DebugNext || e.Signal == DebugError {
+		line += fmt.Sprintf(" %v", e.Item)
+	}
+	return line + "\n"
+}
+
+var debugEnabled int32 = 1
+
+// EnableDebug switches every Debug operator on or off at once. Debug is
+// enabled by default; once disabled it passes items through silently.
+func EnableDebug(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&debugEnabled, v)
+}
+
+// goroutineID parses the ID of the calling goroutine from its stack trace.
+// It is only meant for diagnostics.
+func goroutineID() uint64 {
+	buf := make([]byte, 64)
+	buf = buf[:runtime.Stack(buf, false)]
+	buf = bytes.TrimPrefix(buf, []byte("goroutine "))
+	if i := bytes.IndexByte(buf, ' '); i >= 0 {
+		buf = buf[:i]
+	}
+	id, _ := strconv.ParseUint(string(buf), 10, 64)
+	return id
+}
+
+// Debug passes the items of the original Observable through unchanged while
+// writing a line for every signal, tagged with label, a timestamp and the
+// ID of the goroutine delivering it. It writes to os.Stderr unless
+// configured otherwise, and can be silenced globally with EnableDebug.
+func (o Observable) Debug(label string, opts ...DebugOption) Observable {
+	config := debugConfig{writer: os.Stderr, format: formatDebugEvent, clock: SystemClock}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	report := func(signal DebugSignal, item interface{}) {
+		if atomic.LoadInt32(&debugEnabled) == 0 {
+			return
+		}
+		io.WriteString(config.writer, config.format(DebugEvent{
+			Label:     label,
+			Signal:    signal,
+			Item:      item,
+			Time:      config.clock.Now(),
+			Goroutine: goroutineID(),
+		}))
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		report(DebugSubscribe, nil)
+		failed := false
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				failed = true
+				report(DebugError, item)
+			} else {
+				report(DebugNext, item)
+			}
+			out <- item
+		}
+		if !failed {
+			report(DebugComplete, nil)
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Debug", map[string]interface{}{"label": label}, o)
+}
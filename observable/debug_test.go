@@ -0,0 +1,46 @@
+package observable
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableDebug(t *testing.T) {
+	var buf bytes.Buffer
+	clock := NewManualClock(time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC))
+	items, err := Just(1, 2).Debug("src", DebugWriter(&buf), DebugClock(clock)).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, items)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	assert.Len(t, lines, 4)
+	assert.True(t, strings.HasPrefix(lines[0], "2020-01-02T03:04:05Z [src] goroutine "))
+	assert.True(t, strings.HasSuffix(lines[0], ": subscribe"))
+	assert.True(t, strings.HasSuffix(lines[1], ": next 1"))
+	assert.True(t, strings.HasSuffix(lines[2], ": next 2"))
+	assert.True(t, strings.HasSuffix(lines[3], ": complete"))
+}
+
+func TestObservableDebugFormatAndToggle(t *testing.T) {
+	var signals []DebugSignal
+	format := DebugFormat(func(e DebugEvent) string {
+		signals = append(signals, e.Signal)
+		assert.NotEqual(t, uint64(0), e.Goroutine)
+		return ""
+	})
+
+	_, err := Just(1, errors.New("bang")).Debug("src", format).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []DebugSignal{DebugSubscribe, DebugNext, DebugError}, signals)
+
+	EnableDebug(false)
+	defer EnableDebug(true)
+	signals = nil
+	Just(1).Debug("src", format).ToSlice()
+	assert.Empty(t, signals)
+}
@@ -0,0 +1,128 @@
+package observable
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo"
+	"github.com/reactivex/rxgo/fx"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Deferred builds its Observable only when it is subscribed to, so that
+// nothing is computed for a stream nobody consumes. Every subscription builds
+// a fresh Observable.
+type Deferred struct {
+	factory func() Observable
+}
+
+// Defer creates a Deferred which calls factory on each subscription.
+func Defer(factory func() Observable) Deferred {
+	return Deferred{factory: factory}
+}
+
+// JustDefer creates a Deferred emitting the results of one or more
+// EmittableFuncs, like Just, except that each EmittableFunc is only called at
+// subscription time, after the previous result has been emitted.
+func JustDefer(f fx.EmittableFunc, fs ...fx.EmittableFunc) Deferred {
+	fs = append([]fx.EmittableFunc{f}, fs...)
+	return Defer(func() Observable {
+		source := make(chan interface{})
+		s := register(source)
+		go func() {
+			for _, f := range fs {
+				if s.isCancelled() {
+					break
+				}
+				source <- f()
+			}
+			closeStage(source)
+		}()
+		return Observable(source)
+	})
+}
+
+// FromDefer creates a Deferred emitting the items of the Iterator returned
+// by factory, which is only called at subscription time.
+func FromDefer(factory func() rx.Iterator) Deferred {
+	return Defer(func() Observable {
+		return From(factory())
+	})
+}
+
+// Observable builds a new Observable from the Deferred.
+func (d Deferred) Observable() Observable {
+	return d.factory()
+}
+
+// Subscribe builds a new Observable from the Deferred and subscribes an
+// EventHandler to it.
+func (d Deferred) Subscribe(handler rx.EventHandler) <-chan subscription.Subscription {
+	return d.factory().Subscribe(handler)
+}
+
+// DelaySubscription returns a Deferred which waits for delay before subscribing
+// to its source, which is useful to stagger the start of many polling
+// pipelines. The Observable is returned straight away and starts emitting
+// once the delay has elapsed.
+func (d Deferred) DelaySubscription(delay time.Duration) Deferred {
+	return d.delayed(func() {
+		time.Sleep(delay)
+	})
+}
+
+// DelaySubscriptionUntil returns a Deferred which only subscribes to its
+// source once trigger emits an item or completes.
+func (d Deferred) DelaySubscriptionUntil(trigger Observable) Deferred {
+	return d.delayed(func() {
+		<-trigger
+	})
+}
+
+func (d Deferred) delayed(wait func()) Deferred {
+	return Defer(func() Observable {
+		out := make(chan interface{})
+		s := register(out)
+		go func() {
+			wait()
+			if !s.isCancelled() {
+				source := d.factory()
+				s.follow(source)
+				for item := range source {
+					out <- item
+				}
+			}
+			closeStage(out)
+		}()
+		return Observable(out)
+	})
+}
+
+// Using returns a Deferred which, on each subscription, acquires a resource
+// with acquire, builds an Observable over it with factory, and calls dispose
+// with the resource once that Observable completes or emits an error. It
+// suits streams over files, connections or response bodies. If acquire
+// fails, its error is emitted and nothing is disposed.
+func Using(acquire func() (interface{}, error), factory func(resource interface{}) Observable, dispose func(resource interface{})) Deferred {
+	return Defer(func() Observable {
+		out := make(chan interface{})
+		s := register(out)
+		go func() {
+			defer closeStage(out)
+			resource, err := acquire()
+			if err != nil {
+				out <- err
+				return
+			}
+			defer dispose(resource)
+			source := factory(resource)
+			s.follow(source)
+			for item := range source {
+				out <- item
+				if _, isErr := item.(error); isErr {
+					return
+				}
+			}
+		}()
+		return Observable(out)
+	})
+}
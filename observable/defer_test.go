@@ -0,0 +1,143 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo"
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/iterable"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJustDeferOperator(t *testing.T) {
+	calls := 0
+	expensive := func() interface{} {
+		calls++
+		return calls
+	}
+
+	myStream := JustDefer(expensive, expensive)
+	<-time.After(10 * time.Millisecond)
+	assert.Equal(t, 0, calls)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := myStream.Subscribe(onNext)
+	<-sub
+	sub = myStream.Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{1, 2, 3, 4}, nums)
+}
+
+func TestFromDeferOperator(t *testing.T) {
+	built := false
+	myStream := FromDefer(func() rx.Iterator {
+		built = true
+		it, _ := iterable.New([]interface{}{1, 2})
+		return it
+	})
+	assert.False(t, built)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := myStream.Observable().Map(func(item interface{}) interface{} {
+		return item.(int) * 10
+	}).Subscribe(onNext)
+	<-sub
+
+	assert.True(t, built)
+	assert.Exactly(t, []int{10, 20}, nums)
+}
+
+func TestDeferredDelaySubscription(t *testing.T) {
+	var subscribed time.Time
+	start := time.Now()
+	o := Defer(func() Observable {
+		subscribed = time.Now()
+		return Just(1)
+	}).DelaySubscription(20 * time.Millisecond).Observable()
+
+	item, err := o.BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+	assert.True(t, subscribed.Sub(start) >= 20*time.Millisecond)
+}
+
+func TestDeferredDelaySubscriptionUntil(t *testing.T) {
+	trigger := make(chan interface{})
+	built := make(chan struct{}, 1)
+	o := Defer(func() Observable {
+		built <- struct{}{}
+		return Just(1)
+	}).DelaySubscriptionUntil(Observable(trigger)).Observable()
+
+	select {
+	case <-built:
+		assert.Fail(t, "subscribed before the trigger")
+	case <-time.After(10 * time.Millisecond):
+	}
+
+	close(trigger)
+	item, err := o.BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+}
+
+func TestUsing(t *testing.T) {
+	acquired, disposed := 0, 0
+	stream := Using(func() (interface{}, error) {
+		acquired++
+		return []interface{}{1, 2}, nil
+	}, func(resource interface{}) Observable {
+		items := resource.([]interface{})
+		return Just(items[0], items[1:]...)
+	}, func(interface{}) {
+		disposed++
+	})
+	assert.Equal(t, 0, acquired)
+
+	items, err := stream.Observable().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, items)
+	assert.Equal(t, 1, acquired)
+	assert.Equal(t, 1, disposed)
+}
+
+func TestUsingWithErrors(t *testing.T) {
+	disposed := false
+	_, err := Using(func() (interface{}, error) {
+		return nil, errors.New("unavailable")
+	}, func(interface{}) Observable {
+		return Empty()
+	}, func(interface{}) {
+		disposed = true
+	}).Observable().ToSlice()
+	assert.EqualError(t, err, "unavailable")
+	assert.False(t, disposed)
+
+	done := make(chan struct{})
+	_, err = Using(func() (interface{}, error) {
+		return "conn", nil
+	}, func(interface{}) Observable {
+		return Just(1, errors.New("bang"))
+	}, func(resource interface{}) {
+		assert.Equal(t, "conn", resource)
+		close(done)
+	}).Observable().ToSlice()
+	assert.EqualError(t, err, "bang")
+	<-done
+}
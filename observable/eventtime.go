@@ -0,0 +1,91 @@
+package observable
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/fx"
+)
+
+// LatePolicy decides what EventTimeWindowAggregate does with a late item,
+// one whose windows have all been closed by the watermark.
+type LatePolicy struct {
+	lateness   time.Duration
+	sideOutput *DeadLetterSink
+}
+
+// LateDrop discards late items.
+var LateDrop = LatePolicy{}
+
+// LateUpdate folds late items into their windows for up to allowed past the
+// window end, emitting the updated WindowResult with Late set. Later items
+// are discarded.
+func LateUpdate(allowed time.Duration) LatePolicy {
+	return LatePolicy{lateness: allowed}
+}
+
+// LateSideOutput sends late items to sink.
+func LateSideOutput(sink *DeadLetterSink) LatePolicy {
+	return LatePolicy{sideOutput: sink}
+}
+
+// EventTimeWindowAggregate is WindowAggregate driven by event time: items are
+// assigned to windows by the time returned by timestamp, and windows are
+// aligned on the Unix epoch. The watermark trails the latest timestamp seen
+// by maxOutOfOrder, and a window closes once the watermark passes its end,
+// so items may arrive out of order by up to maxOutOfOrder. Items arriving
+// after all their windows closed are handled according to an optional
+// LatePolicy, which defaults to LateDrop.
+//
+// The watermark only advances as items arrive; windows still open when the
+// original Observable completes are emitted straight away. Like
+// WindowAggregate, it emits an error for an invalid window.
+func (o Observable) EventTimeWindowAggregate(window Window, timestamp fx.TimestampFunc, maxOutOfOrder time.Duration,
+	initial fx.EmittableFunc, fold fx.ScannableFunc, late ...LatePolicy) Observable {
+	policy := LateDrop
+	if len(late) > 0 {
+		policy = late[0]
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		defer closeStage(out)
+		if err := window.validate(); err != nil {
+			out <- err
+			return
+		}
+		w := &windower{window: window, initial: initial, fold: fold, retain: policy.lateness > 0}
+		emit := func(results []WindowResult) {
+			for _, result := range results {
+				out <- result
+			}
+		}
+
+		var watermark time.Time
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				return
+			}
+			at := timestamp(item)
+			if w.late(at, watermark) {
+				switch {
+				case policy.lateness > 0:
+					emit(w.update(at, item, watermark.Add(-policy.lateness)))
+				case policy.sideOutput != nil:
+					policy.sideOutput.Send(item, errors.New(errors.ObservableError, "item arrived after the watermark"))
+				}
+				continue
+			}
+			w.panes, _ = w.assign(w.panes, at, item, watermark)
+			if mark := at.Add(-maxOutOfOrder); mark.After(watermark) {
+				watermark = mark
+				emit(w.closeUntil(watermark))
+				w.expire(watermark.Add(-policy.lateness))
+			}
+		}
+		emit(w.flush())
+	}()
+	return describe(Observable(out), "EventTimeWindowAggregate", nil, o)
+}
@@ -0,0 +1,71 @@
+package observable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type event struct {
+	at    int
+	value int
+}
+
+func eventTimestamp(item interface{}) time.Time {
+	return time.Unix(0, 0).Add(time.Duration(item.(event).at) * time.Millisecond)
+}
+
+func sumEvents(acc, item interface{}) interface{} {
+	return acc.(int) + item.(event).value
+}
+
+func eventResults(t *testing.T, o Observable) []WindowResult {
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	results := make([]WindowResult, len(items))
+	for i, item := range items {
+		results[i] = item.(WindowResult)
+	}
+	return results
+}
+
+func TestEventTimeWindowAggregate(t *testing.T) {
+	// The event at 8 arrives out of order but before the watermark passes 10.
+	results := eventResults(t, Just(event{1, 1}, event{11, 2}, event{8, 4}, event{22, 8}, event{3, 16}).
+		EventTimeWindowAggregate(TumblingWindow(10*time.Millisecond), eventTimestamp, 5*time.Millisecond, zero, sumEvents))
+
+	assert.Equal(t, []interface{}{5, 2, 8}, values(results))
+	assert.Equal(t, eventTimestamp(event{at: 10}), results[1].Start)
+	assert.False(t, results[0].Late)
+}
+
+func TestEventTimeWindowAggregateLateUpdate(t *testing.T) {
+	results := eventResults(t, Just(event{1, 1}, event{15, 2}, event{3, 4}, event{40, 8}, event{5, 16}).
+		EventTimeWindowAggregate(TumblingWindow(10*time.Millisecond), eventTimestamp, 0, zero, sumEvents,
+			LateUpdate(10*time.Millisecond)))
+
+	assert.Equal(t, []interface{}{1, 5, 2, 8}, values(results))
+	assert.False(t, results[0].Late)
+	assert.True(t, results[1].Late)
+	assert.False(t, results[2].Late)
+}
+
+func TestEventTimeWindowAggregateLateSideOutput(t *testing.T) {
+	sink := NewDeadLetterSink(1)
+	results := eventResults(t, Just(event{1, 1}, event{15, 2}, event{3, 4}).
+		EventTimeWindowAggregate(TumblingWindow(10*time.Millisecond), eventTimestamp, 0, zero, sumEvents,
+			LateSideOutput(sink)))
+	sink.Close()
+
+	assert.Equal(t, []interface{}{1, 2}, values(results))
+	letter := <-sink.Observable()
+	assert.Equal(t, event{3, 4}, letter.(DeadLetter).Item)
+}
+
+func TestEventTimeSessionWindowAggregate(t *testing.T) {
+	results := eventResults(t, Just(event{0, 1}, event{20, 2}, event{3, 4}, event{50, 8}).
+		EventTimeWindowAggregate(SessionWindow(5*time.Millisecond), eventTimestamp, 20*time.Millisecond, zero, sumEvents))
+
+	assert.Equal(t, []interface{}{5, 2, 8}, values(results))
+}
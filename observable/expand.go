@@ -0,0 +1,83 @@
+package observable
+
+// Expand emits the items of the original Observable and recursively the
+// items of the Observable which apply returns for every emitted item, until
+// every such Observable is exhausted. It is the natural shape for crawling
+// paginated APIs or walking trees; apply may return nil for a leaf.
+//
+// At most an optional concurrency, 1 by default, of the Observables returned
+// by apply are consumed at once. The first error terminates the expansion.
+func (o Observable) Expand(apply func(interface{}) Observable, concurrency ...uint) Observable {
+	limit := uint(1)
+	if len(concurrency) > 0 && concurrency[0] > 0 {
+		limit = concurrency[0]
+	}
+
+	out := make(chan interface{})
+	s := register(out, o)
+	go func() {
+		defer closeStage(out)
+		results := make(chan interface{})
+		finished := make(chan struct{})
+		quit := make(chan struct{})
+		defer close(quit)
+
+		expand := func(item interface{}) {
+			defer func() {
+				select {
+				case finished <- struct{}{}:
+				case <-quit:
+				}
+			}()
+			inner := apply(item)
+			if inner == nil {
+				return
+			}
+			for child := range inner {
+				select {
+				case results <- child:
+				case <-quit:
+					unsubscribe(inner)
+					return
+				case <-s.cancelled():
+					unsubscribe(inner)
+					return
+				}
+			}
+		}
+
+		var queue []interface{}
+		active := uint(0)
+		in := o
+		for in != nil || active > 0 || len(queue) > 0 {
+			for active < limit && len(queue) > 0 {
+				active++
+				go expand(queue[0])
+				queue = queue[1:]
+			}
+
+			var item interface{}
+			select {
+			case next, ok := <-in:
+				if !ok {
+					in = nil
+					continue
+				}
+				item = next
+			case item = <-results:
+			case <-finished:
+				active--
+				continue
+			}
+
+			out <- item
+			if _, isErr := item.(error); isErr {
+				return
+			}
+			if !s.isCancelled() {
+				queue = append(queue, item)
+			}
+		}
+	}()
+	return describe(Observable(out), "Expand", nil, o)
+}
@@ -0,0 +1,47 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableExpand(t *testing.T) {
+	// Walk a binary tree of the numbers below 8 rooted at 1.
+	children := func(item interface{}) Observable {
+		n := item.(int)
+		if 2*n >= 8 {
+			return nil
+		}
+		return Just(2*n, 2*n+1)
+	}
+
+	items, err := Just(1).Expand(children).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3, 4, 5, 6, 7}, items)
+
+	items, err = Just(1).Expand(children, 4).ToSlice()
+	assert.Nil(t, err)
+	assert.ElementsMatch(t, []interface{}{1, 2, 3, 4, 5, 6, 7}, items)
+}
+
+func TestObservableExpandPages(t *testing.T) {
+	// Fetch pages until one has no successor.
+	items, err := Just(0).Expand(func(page interface{}) Observable {
+		if page.(int) == 3 {
+			return Empty()
+		}
+		return Just(page.(int) + 1)
+	}).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2, 3}, items)
+}
+
+func TestObservableExpandError(t *testing.T) {
+	items, err := Just(1).Expand(func(item interface{}) Observable {
+		return Just(item.(int)+1, errors.New("bang"))
+	}).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{1, 2}, items)
+}
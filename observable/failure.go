@@ -0,0 +1,100 @@
+package observable
+
+import "github.com/reactivex/rxgo/fx"
+
+// FailurePolicy decides what happens to an item whose fallible predicate, as
+// used by MapE and FilterE, returns an error.
+type FailurePolicy struct {
+	skip       bool
+	deadLetter *DeadLetterSink
+}
+
+var (
+	// FailStop emits the error and terminates the stream.
+	FailStop = FailurePolicy{}
+
+	// FailSkip discards the item and carries on.
+	FailSkip = FailurePolicy{skip: true}
+)
+
+// FailDeadLetter sends the item and its error to sink and carries on.
+func FailDeadLetter(sink *DeadLetterSink) FailurePolicy {
+	return FailurePolicy{skip: true, deadLetter: sink}
+}
+
+// fail applies the policy to an item which failed with err, and reports
+// whether the stream must terminate.
+func (p FailurePolicy) fail(out chan<- interface{}, item interface{}, err error) bool {
+	if !p.skip {
+		out <- err
+		return true
+	}
+	if p.deadLetter != nil {
+		p.deadLetter.Send(item, err)
+	}
+	return false
+}
+
+// MapE maps a fallible MappableErrFunc predicate to each item in the original
+// Observable. Items for which it fails are handled according to an optional
+// FailurePolicy, which defaults to FailStop.
+func (o Observable) MapE(apply fx.MappableErrFunc, policy ...FailurePolicy) Observable {
+	onFailure := FailStop
+	if len(policy) > 0 {
+		onFailure = policy[0]
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+			mapped, err := apply(item)
+			if err != nil {
+				if onFailure.fail(out, item, err) {
+					break
+				}
+				continue
+			}
+			out <- mapped
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "MapE", nil, o)
+}
+
+// FilterE filters items in the original Observable with a fallible
+// FilterableErrFunc predicate. Items for which it fails are handled according
+// to an optional FailurePolicy, which defaults to FailStop.
+func (o Observable) FilterE(apply fx.FilterableErrFunc, policy ...FailurePolicy) Observable {
+	onFailure := FailStop
+	if len(policy) > 0 {
+		onFailure = policy[0]
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+			keep, err := apply(item)
+			if err != nil {
+				if onFailure.fail(out, item, err) {
+					break
+				}
+				continue
+			}
+			if keep {
+				out <- item
+			}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "FilterE", nil, o)
+}
@@ -0,0 +1,59 @@
+package observable
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func atoi(item interface{}) (interface{}, error) {
+	return strconv.Atoi(item.(string))
+}
+
+func TestObservableMapE(t *testing.T) {
+	items, err := Just("1", "x", "3").MapE(atoi).ToSlice()
+
+	assert.Exactly(t, []interface{}{1}, items)
+	assert.Error(t, err)
+}
+
+func TestObservableMapEWithSkip(t *testing.T) {
+	items, err := Just("1", "x", "3").MapE(atoi, FailSkip).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{1, 3}, items)
+}
+
+func TestObservableMapEWithDeadLetter(t *testing.T) {
+	sink := NewDeadLetterSink(1)
+	items, err := Just("1", "x", "3").MapE(atoi, FailDeadLetter(sink)).ToSlice()
+	sink.Close()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{1, 3}, items)
+
+	letters, _ := sink.Observable().ToSlice()
+	if assert.Len(t, letters, 1) {
+		assert.Equal(t, "x", letters[0].(DeadLetter).Item)
+		assert.Error(t, letters[0].(DeadLetter).Reason)
+	}
+}
+
+func TestObservableFilterE(t *testing.T) {
+	positive := func(item interface{}) (bool, error) {
+		if item.(int) == 0 {
+			return false, errors.New("zero")
+		}
+		return item.(int) > 0, nil
+	}
+
+	items, err := Just(1, -2, 0, 3).FilterE(positive).ToSlice()
+	assert.Exactly(t, []interface{}{1}, items)
+	assert.EqualError(t, err, "zero")
+
+	items, err = Just(1, -2, 0, 3).FilterE(positive, FailSkip).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{1, 3}, items)
+}
@@ -0,0 +1,312 @@
+package observable
+
+import (
+	"sync"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// step is one synchronous stage of a fused chain. It returns the item to
+// pass on, whether to keep it, and whether the stage is done after it. A nil
+// step is done before it sees any item, as Take(0) is.
+type step func(item interface{}) (next interface{}, keep bool, done bool)
+
+func mapStage(apply fx.MappableFunc) func() step {
+	return func() step {
+		return func(item interface{}) (interface{}, bool, bool) {
+			return apply(item), true, false
+		}
+	}
+}
+
+func filterStage(apply fx.FilterableFunc) func() step {
+	return func() step {
+		return func(item interface{}) (interface{}, bool, bool) {
+			return item, apply(item), false
+		}
+	}
+}
+
+func takeStage(nth uint) func() step {
+	return func() step {
+		if nth == 0 {
+			return nil
+		}
+		taken := uint(0)
+		return func(item interface{}) (interface{}, bool, bool) {
+			taken++
+			return item, true, taken == nth
+		}
+	}
+}
+
+func takeWhileStage(apply fx.FilterableFunc) func() step {
+	return func() step {
+		return func(item interface{}) (interface{}, bool, bool) {
+			if !apply(item) {
+				return nil, false, true
+			}
+			return item, true, false
+		}
+	}
+}
+
+func skipStage(nth uint) func() step {
+	return func() step {
+		skipped := uint(0)
+		return func(item interface{}) (interface{}, bool, bool) {
+			if skipped < nth {
+				skipped++
+				return nil, false, false
+			}
+			return item, true, false
+		}
+	}
+}
+
+func skipWhileStage(apply fx.FilterableFunc) func() step {
+	return func() step {
+		skipping := true
+		return func(item interface{}) (interface{}, bool, bool) {
+			if skipping && apply(item) {
+				return nil, false, false
+			}
+			skipping = false
+			return item, true, false
+		}
+	}
+}
+
+func distinctUntilChangedStage(apply fx.KeySelectorFunc) func() step {
+	return func() step {
+		var current interface{}
+		return func(item interface{}) (interface{}, bool, bool) {
+			key := apply(item)
+			if current == key {
+				return nil, false, false
+			}
+			current = key
+			return item, true, false
+		}
+	}
+}
+
+// Fusion is a chain of synchronous operators which runs in a single
+// goroutine, instead of the goroutine and channel per operator of a chain
+// of Observable methods. It gives the same results as chaining the
+// corresponding operators, at a fraction of the scheduling cost for deep
+// pipelines. Map, Filter, Take and the other synchronous operators called on
+// an Observable are fused this way as they are chained; a Fusion builds such
+// a chain ahead of time, as an Operator. The zero Fusion passes items
+// through.
+type Fusion struct {
+	stages []func() step
+}
+
+// Fuse starts an empty Fusion.
+func Fuse() Fusion {
+	return Fusion{}
+}
+
+func (f Fusion) then(stage func() step) Fusion {
+	stages := make([]func() step, len(f.stages), len(f.stages)+1)
+	copy(stages, f.stages)
+	return Fusion{stages: append(stages, stage)}
+}
+
+// Map appends a Map stage.
+func (f Fusion) Map(apply fx.MappableFunc) Fusion {
+	return f.then(mapStage(apply))
+}
+
+// Filter appends a Filter stage.
+func (f Fusion) Filter(apply fx.FilterableFunc) Fusion {
+	return f.then(filterStage(apply))
+}
+
+// Take appends a Take stage.
+func (f Fusion) Take(nth uint) Fusion {
+	return f.then(takeStage(nth))
+}
+
+// Operator returns the Fusion as an Operator, for use with Pipe.
+func (f Fusion) Operator() Operator {
+	return f.apply
+}
+
+func (f Fusion) apply(o Observable) Observable {
+	return fuse(o, nil, f.stages...)
+}
+
+// chains maps the unbuffered output of a running fused chain to its *chain,
+// so that an operator applied to the output can join the chain instead of
+// reading it from a goroutine of its own.
+var chains sync.Map
+
+// chain runs the steps of fused operators on the items of in, in a single
+// goroutine. Joining a chain hands its output over: the steps of the new
+// operator are added and the chain emits on a new channel, while the former
+// output is closed.
+type chain struct {
+	in Observable
+
+	mu      sync.Mutex
+	steps   []step
+	out     chan interface{}
+	retired []chan interface{} // outputs handed over, not yet closed
+	changed chan struct{}      // closed once the chain is joined
+	done    bool
+}
+
+// fuse returns an Observable emitting the items of o through stages, run by
+// the chain emitting o if there is one, and by a new chain otherwise. A
+// chain with a buffered output, configured by opts, can't be joined, as the
+// items in its buffer have already left it.
+func fuse(o Observable, opts []Option, stages ...func() step) Observable {
+	out := newChannel(opts)
+	if v, ok := chains.Load(o); ok && v.(*chain).join(o, out, stages) {
+		return Observable(out)
+	}
+
+	c := &chain{in: o, out: out, changed: make(chan struct{})}
+	for _, stage := range stages {
+		c.steps = append(c.steps, stage())
+	}
+	register(out, o)
+	if cap(out) == 0 {
+		chains.Store(Observable(out), c)
+	}
+	go c.run()
+	return Observable(out)
+}
+
+// join adds stages to the chain and moves it to out, provided the chain
+// still emits on o.
+func (c *chain) join(o Observable, out chan interface{}, stages []func() step) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.done || Observable(c.out) != o {
+		return false
+	}
+	steps := c.steps[:len(c.steps):len(c.steps)]
+	for _, stage := range stages {
+		steps = append(steps, stage())
+	}
+	c.steps = steps
+	register(out, c.in)
+	chains.Delete(o)
+	if cap(out) == 0 {
+		chains.Store(Observable(out), c)
+	}
+	c.retired = append(c.retired, c.out)
+	c.out = out
+	close(c.changed)
+	c.changed = make(chan struct{})
+	return true
+}
+
+// closeRetired closes the outputs handed over, which the chain doesn't
+// emit on anymore.
+func closeRetired(retired []chan interface{}) {
+	for _, out := range retired {
+		stages.Delete(Observable(out))
+		close(out)
+	}
+}
+
+func (c *chain) run() {
+	c.mu.Lock()
+	steps, out, changed := c.steps, c.out, c.changed
+	c.mu.Unlock()
+
+	// update takes on the steps and output of the chain once it is joined,
+	// returning the steps added since the last update.
+	update := func() []step {
+		c.mu.Lock()
+		added := c.steps[len(steps):]
+		steps, out, changed = c.steps, c.out, c.changed
+		retired := c.retired
+		c.retired = nil
+		c.mu.Unlock()
+		closeRetired(retired)
+		return added
+	}
+
+	if exhausted(steps) {
+		c.finish()
+		return
+	}
+	for {
+		select {
+		case item, ok := <-c.in:
+			if !ok {
+				c.finish()
+				return
+			}
+			item, keep, finished := pass(steps, item)
+			for keep {
+				select {
+				case out <- item:
+					keep = false
+				case <-changed:
+					// The item still has to go through the steps added.
+					added := update()
+					if exhausted(added) {
+						c.finish()
+						return
+					}
+					var done bool
+					item, keep, done = pass(added, item)
+					finished = finished || done
+				}
+			}
+			// A step which is done lets no further item through.
+			if finished {
+				c.finish()
+				return
+			}
+		case <-changed:
+			if exhausted(update()) {
+				c.finish()
+				return
+			}
+		}
+	}
+}
+
+// finish closes the output of the chain, releasing its input.
+func (c *chain) finish() {
+	c.mu.Lock()
+	c.done = true
+	out, retired := c.out, c.retired
+	c.retired = nil
+	c.mu.Unlock()
+	chains.Delete(Observable(out))
+	closeRetired(retired)
+	closeStage(out)
+}
+
+// exhausted reports whether one of steps is done before any item.
+func exhausted(steps []step) bool {
+	for _, s := range steps {
+		if s == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// pass runs item through steps, returning the item they let through, if
+// any, and whether one of them is done.
+func pass(steps []step, item interface{}) (interface{}, bool, bool) {
+	finished := false
+	for _, s := range steps {
+		var keep, done bool
+		item, keep, done = s(item)
+		finished = finished || done
+		if !keep {
+			return nil, false, finished
+		}
+	}
+	return item, true, finished
+}
@@ -0,0 +1,147 @@
+package observable
+
+import (
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFusion(t *testing.T) {
+	double := func(item interface{}) interface{} { return item.(int) * 2 }
+	big := func(item interface{}) bool { return item.(int) > 4 }
+
+	fused := Fuse().Map(double).Filter(big).Take(2)
+	items, err := Range(0, 10).Pipe(fused.Operator()).ToSlice()
+	assert.Nil(t, err)
+
+	chained, _ := Range(0, 10).Map(double).Filter(big).Take(2).ToSlice()
+	assert.Equal(t, chained, items)
+	assert.Equal(t, []interface{}{6, 8}, items)
+
+	// A Fusion is reusable, with fresh Take state on each application.
+	items, err = Range(0, 10).Pipe(fused.Operator()).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{6, 8}, items)
+}
+
+func TestFusionTakeThenFilter(t *testing.T) {
+	odd := func(item interface{}) bool { return item.(int)%2 == 1 }
+	items, err := Range(0, 10).Pipe(Fuse().Take(4).Filter(odd).Operator()).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 3}, items)
+
+	items, err = Range(0, 10).Pipe(Fuse().Take(0).Operator()).ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+
+	items, err = Just(1).Pipe(Fuse().Operator()).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestFusionTakeZero(t *testing.T) {
+	for _, o := range []Observable{
+		Never().Pipe(Fuse().Take(0).Operator()),
+		Never().Map(increment).Take(0),
+	} {
+		select {
+		case _, ok := <-o:
+			assert.False(t, ok)
+		case <-time.After(time.Second):
+			t.Fatal("Take(0) waited for an item")
+		}
+	}
+}
+
+func TestChainedOperatorsFuse(t *testing.T) {
+	double := func(item interface{}) interface{} { return item.(int) * 2 }
+	big := func(item interface{}) bool { return item.(int) > 4 }
+	in := make(chan interface{})
+
+	baseline := runtime.NumGoroutine()
+	o := FromChannel(in).
+		Skip(1).
+		SkipWhile(func(item interface{}) bool { return item.(int) < 1 }).
+		Map(double).
+		Filter(big).
+		DistinctUntilChanged(func(item interface{}) interface{} { return item }).
+		Map(increment).
+		TakeWhile(func(item interface{}) bool { return item.(int) < 100 }).
+		Take(2)
+	if n := runtime.NumGoroutine(); n > baseline+1 {
+		t.Fatalf("%d goroutines for the pipeline, want 1", n-baseline)
+	}
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			in <- i
+		}
+	}()
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{7, 9}, items)
+}
+
+func TestFusionJoinsWhileEmitting(t *testing.T) {
+	double := func(item interface{}) interface{} { return item.(int) * 2 }
+	o := Range(0, 5).Map(double)
+	assert.Equal(t, 0, <-o)
+
+	// The chain is now waiting to emit 2 on o, which it emits through the
+	// joined Filter instead.
+	time.Sleep(10 * time.Millisecond)
+	items, err := o.Filter(func(item interface{}) bool { return item.(int) != 4 }).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 6, 8}, items)
+	_, ok := <-o
+	assert.False(t, ok)
+}
+
+func increment(item interface{}) interface{} {
+	return item.(int) + 1
+}
+
+// unfusedMap is Map as it ran before fusion, with a goroutine and a channel
+// per stage, as a baseline for the benchmarks.
+func unfusedMap(o Observable, apply func(interface{}) interface{}) Observable {
+	out := make(chan interface{})
+	go func() {
+		for item := range o {
+			out <- apply(item)
+		}
+		close(out)
+	}()
+	return Observable(out)
+}
+
+func BenchmarkUnfusedMaps(b *testing.B) {
+	b.ReportAllocs()
+	o := Range(0, b.N)
+	for i := 0; i < 8; i++ {
+		o = unfusedMap(o, increment)
+	}
+	for range o {
+	}
+}
+
+func BenchmarkChainedMaps(b *testing.B) {
+	b.ReportAllocs()
+	o := Range(0, b.N)
+	for i := 0; i < 8; i++ {
+		o = o.Map(increment)
+	}
+	for range o {
+	}
+}
+
+func BenchmarkFusedMaps(b *testing.B) {
+	b.ReportAllocs()
+	f := Fuse()
+	for i := 0; i < 8; i++ {
+		f = f.Map(increment)
+	}
+	for range Range(0, b.N).Pipe(f.Operator()) {
+	}
+}
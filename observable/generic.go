@@ -0,0 +1,33 @@
+//go:build go1.18
+
+package observable
+
+// FromChannelOf creates an Observable emitting the items received on a typed
+// channel, which completes when the channel is closed.
+func FromChannelOf[T any](ch <-chan T) Observable {
+	source := make(chan interface{})
+	go func() {
+		for item := range ch {
+			source <- item
+		}
+		close(source)
+	}()
+	return created("FromChannelOf", Observable(source))
+}
+
+// FromSlice creates an Observable emitting the elements of a slice of any
+// type, without first converting it to a slice of empty interface.
+func FromSlice[T any](items []T) Observable {
+	source := make(chan interface{})
+	s := register(source)
+	go func() {
+		for _, item := range items {
+			if s.isCancelled() {
+				break
+			}
+			source <- item
+		}
+		closeStage(source)
+	}()
+	return created("FromSlice", Observable(source))
+}
@@ -0,0 +1,56 @@
+//go:build go1.18
+
+package observable
+
+import (
+	"testing"
+
+	"github.com/reactivex/rxgo/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromChannelOfOperator(t *testing.T) {
+	ch := make(chan string, 3)
+	ch <- "foo"
+	ch <- "bar"
+	close(ch)
+
+	words := []string{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if word, ok := item.(string); ok {
+			words = append(words, word)
+		}
+	})
+
+	sub := FromChannelOf(ch).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []string{"foo", "bar"}, words)
+}
+
+func TestFromSliceOperator(t *testing.T) {
+	words := []string{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if word, ok := item.(string); ok {
+			words = append(words, word)
+		}
+	})
+
+	sub := FromSlice([]string{"hello", "world"}).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []string{"hello", "world"}, words)
+}
+
+func TestFromSliceOperatorWithEmptySlice(t *testing.T) {
+	done := false
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+
+	sub := FromSlice([]int(nil)).Subscribe(onDone)
+	<-sub
+
+	assert.True(t, done)
+}
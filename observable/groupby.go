@@ -0,0 +1,217 @@
+package observable
+
+import (
+	"container/list"
+	"sync"
+	"time"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// GroupedObservable is an Observable of the items of a GroupBy sharing the
+// same key.
+type GroupedObservable struct {
+	Observable
+	Key interface{}
+
+	group   *group
+	cancels chan<- *group
+	done    <-chan struct{}
+}
+
+// Cancel completes the group and discards its pending items. Later items
+// with the same key open a new group.
+func (g GroupedObservable) Cancel() {
+	g.group.once.Do(func() {
+		select {
+		case g.cancels <- g.group:
+		case <-g.done:
+		}
+	})
+}
+
+type group struct {
+	key      interface{}
+	ch       chan interface{}
+	once     sync.Once
+	closed   bool
+	lastSeen time.Time
+	recent   *list.Element
+}
+
+// GroupEviction bounds the groups a GroupBy keeps open. An evicted group
+// completes, and later items with its key open a new group.
+type GroupEviction struct {
+	// Idle completes groups which received no item for this long. Zero
+	// disables idle eviction.
+	Idle time.Duration
+	// MaxGroups completes the least recently active group when opening a
+	// new one would exceed this many open groups. Zero means unbounded.
+	MaxGroups uint
+}
+
+// groupDispatcher routes the items of a GroupBy to their groups. It is owned
+// by a single goroutine, which also serves group cancellations so that a
+// Cancel never deadlocks against a blocked send.
+type groupDispatcher struct {
+	out     chan interface{}
+	groups  map[interface{}]*group
+	cancels chan *group
+	done    chan struct{}
+	buffer  uint
+
+	eviction GroupEviction
+	recent   *list.List // open groups, most recently active first
+}
+
+func (d *groupDispatcher) close(g *group) {
+	if g.closed {
+		return
+	}
+	g.closed = true
+	if d.groups[g.key] == g {
+		delete(d.groups, g.key)
+	}
+	d.recent.Remove(g.recent)
+	closeStage(g.ch)
+}
+
+func (d *groupDispatcher) touch(g *group) {
+	g.lastSeen = time.Now()
+	d.recent.MoveToFront(g.recent)
+}
+
+// evictIdle completes every group idle for longer than the eviction policy
+// allows.
+func (d *groupDispatcher) evictIdle() {
+	deadline := time.Now().Add(-d.eviction.Idle)
+	for e := d.recent.Back(); e != nil; e = d.recent.Back() {
+		g := e.Value.(*group)
+		if g.lastSeen.After(deadline) {
+			return
+		}
+		d.close(g)
+	}
+}
+
+// send delivers item on ch, which belongs to g or is the outer Observable if
+// g is nil, while serving cancellations. It reports false if g was
+// cancelled before the item could be delivered.
+func (d *groupDispatcher) send(ch chan<- interface{}, g *group, item interface{}) bool {
+	for {
+		select {
+		case ch <- item:
+			return true
+		case c := <-d.cancels:
+			d.close(c)
+			if c == g {
+				return false
+			}
+		}
+	}
+}
+
+func (d *groupDispatcher) open(key interface{}) *group {
+	if max := d.eviction.MaxGroups; max > 0 && uint(len(d.groups)) >= max {
+		d.close(d.recent.Back().Value.(*group))
+	}
+	g := &group{key: key, ch: make(chan interface{}, d.buffer), lastSeen: time.Now()}
+	g.recent = d.recent.PushFront(g)
+	d.groups[key] = g
+	grouped := GroupedObservable{
+		Observable: Observable(g.ch),
+		Key:        key,
+		group:      g,
+		cancels:    d.cancels,
+		done:       d.done,
+	}
+	register(g.ch).onCancel = grouped.Cancel
+	d.send(d.out, nil, grouped)
+	return g
+}
+
+func (d *groupDispatcher) finish(err error) {
+	if err != nil {
+		for _, g := range d.groups {
+			d.send(g.ch, g, err)
+		}
+		d.send(d.out, nil, err)
+	}
+	close(d.done)
+	for _, g := range d.groups {
+		d.close(g)
+	}
+	closeStage(d.out)
+}
+
+// GroupBy divides the original Observable into a GroupedObservable per
+// distinct key returned by apply, emitted when the first item with that key
+// arrives. Keys must be comparable.
+//
+// Every group must be consumed or cancelled: a group that falls behind
+// blocks the whole GroupBy once its optional buffer, of 0 items by default,
+// is full. An error is delivered to every open group as well as to the
+// outer Observable.
+func (o Observable) GroupBy(apply fx.KeySelectorFunc, buffer ...uint) Observable {
+	return o.groupBy("GroupBy", apply, GroupEviction{}, buffer)
+}
+
+// GroupByEvicting is GroupBy with groups completed according to eviction,
+// so that streams with many short-lived keys, such as user IDs, don't
+// accumulate open groups forever.
+func (o Observable) GroupByEvicting(apply fx.KeySelectorFunc, eviction GroupEviction, buffer ...uint) Observable {
+	return o.groupBy("GroupByEvicting", apply, eviction, buffer)
+}
+
+// groupBy implements GroupBy and GroupByEvicting, describing the result as
+// name.
+func (o Observable) groupBy(name string, apply fx.KeySelectorFunc, eviction GroupEviction, buffer []uint) Observable {
+	d := &groupDispatcher{
+		out:      make(chan interface{}),
+		groups:   make(map[interface{}]*group),
+		cancels:  make(chan *group),
+		done:     make(chan struct{}),
+		eviction: eviction,
+		recent:   list.New(),
+	}
+	if len(buffer) > 0 {
+		d.buffer = buffer[0]
+	}
+	register(d.out, o)
+
+	go func() {
+		var tick <-chan time.Time
+		if eviction.Idle > 0 {
+			ticker := time.NewTicker(eviction.Idle / 2)
+			defer ticker.Stop()
+			tick = ticker.C
+		}
+		for {
+			select {
+			case item, ok := <-o:
+				if !ok {
+					d.finish(nil)
+					return
+				}
+				if err, isErr := item.(error); isErr {
+					d.finish(err)
+					return
+				}
+				key := apply(item)
+				g, ok := d.groups[key]
+				if !ok {
+					g = d.open(key)
+				}
+				if !g.closed {
+					d.touch(g)
+					d.send(g.ch, g, item)
+				}
+			case c := <-d.cancels:
+				d.close(c)
+			case <-tick:
+				d.evictIdle()
+			}
+		}
+	}()
+	return describe(Observable(d.out), name, nil, o)
+}
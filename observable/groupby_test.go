@@ -0,0 +1,131 @@
+package observable
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableGroupBy(t *testing.T) {
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	groups := make(map[interface{}][]interface{})
+
+	for item := range Range(0, 10).GroupBy(func(i interface{}) interface{} {
+		return i.(int) % 3
+	}) {
+		g := item.(GroupedObservable)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, err := g.ToSlice()
+			assert.Nil(t, err)
+			mu.Lock()
+			groups[g.Key] = items
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, map[interface{}][]interface{}{
+		0: {0, 3, 6, 9},
+		1: {1, 4, 7},
+		2: {2, 5, 8},
+	}, groups)
+}
+
+func TestObservableGroupByError(t *testing.T) {
+	var groupErr error
+	var wg sync.WaitGroup
+	var outerErr error
+	for item := range Just(1, errors.New("bang")).GroupBy(func(i interface{}) interface{} {
+		return i
+	}) {
+		switch item := item.(type) {
+		case GroupedObservable:
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				_, groupErr = item.ToSlice()
+			}()
+		case error:
+			outerErr = item
+		}
+	}
+	wg.Wait()
+	assert.EqualError(t, outerErr, "bang")
+	assert.EqualError(t, groupErr, "bang")
+}
+
+func TestObservableGroupByCancel(t *testing.T) {
+	opened := 0
+	var evens []interface{}
+	var wg sync.WaitGroup
+	for item := range Just(1, 2, 3, 4, 5, 6).GroupBy(func(i interface{}) interface{} {
+		return i.(int) % 2
+	}) {
+		g := item.(GroupedObservable)
+		opened++
+		if g.Key == 1 {
+			g.Cancel()
+			g.Cancel()
+			for range g.Observable {
+			}
+			continue
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			evens, _ = g.ToSlice()
+		}()
+	}
+	wg.Wait()
+
+	// The cancelled odd group reopens for each later odd item.
+	assert.Equal(t, 4, opened)
+	assert.Equal(t, []interface{}{2, 4, 6}, evens)
+}
+
+func TestObservableGroupByMaxGroups(t *testing.T) {
+	var keys []interface{}
+	var wg sync.WaitGroup
+	for item := range Just("a", "b", "c", "a").GroupByEvicting(func(i interface{}) interface{} {
+		return i
+	}, GroupEviction{MaxGroups: 2}) {
+		g := item.(GroupedObservable)
+		keys = append(keys, g.Key)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			items, _ := g.ToSlice()
+			assert.Equal(t, []interface{}{g.Key}, items)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, []interface{}{"a", "b", "c", "a"}, keys)
+}
+
+func TestObservableGroupByIdle(t *testing.T) {
+	in := make(chan interface{})
+	groups := FromChannel(in).GroupByEvicting(func(i interface{}) interface{} {
+		return i
+	}, GroupEviction{Idle: 10 * time.Millisecond})
+
+	go func() { in <- "x" }()
+	g := (<-groups).(GroupedObservable)
+	assert.Equal(t, "x", <-g.Observable)
+
+	select {
+	case _, ok := <-g.Observable:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		assert.Fail(t, "idle group was not evicted")
+	}
+
+	close(in)
+	_, ok := <-groups
+	assert.False(t, ok)
+}
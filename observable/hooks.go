@@ -0,0 +1,156 @@
+package observable
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/reactivex/rxgo/observer"
+)
+
+// CreateHook observes or wraps every Observable created by a source such
+// as Just, From, Range, Interval or Create.
+type CreateHook func(Observable) Observable
+
+// SubscribeHook observes or wraps every Observer subscribed with Subscribe.
+type SubscribeHook func(observer.Observer) observer.Observer
+
+// NextHook observes or replaces every item delivered by Subscribe. It may
+// return an error to fail the subscription.
+type NextHook func(item interface{}) interface{}
+
+// ErrorHook observes or replaces every error delivered by Subscribe.
+type ErrorHook func(err error) error
+
+// hookSet is an immutable set of hooks, replaced as a whole when a hook is
+// added so that reading it takes a single atomic load.
+type hookSet struct {
+	create    []CreateHook
+	subscribe []SubscribeHook
+	next      []NextHook
+	err       []ErrorHook
+}
+
+var (
+	hooksMu sync.Mutex
+	hooks   atomic.Value
+
+	// observableHooks holds the SubscribeHooks added to a single Observable
+	// with OnSubscribeTo, as a []SubscribeHook keyed by the Observable.
+	observableHooks sync.Map
+)
+
+func init() {
+	hooks.Store(&hookSet{})
+}
+
+func currentHooks() *hookSet {
+	return hooks.Load().(*hookSet)
+}
+
+// updateHooks applies update to a copy of the current hooks and installs it.
+func updateHooks(update func(*hookSet)) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	set := *currentHooks()
+	update(&set)
+	hooks.Store(&set)
+}
+
+// OnObservableCreate adds a hook called with every Observable created by a
+// source. Hooks run in the order they were added, each one receiving the
+// result of the previous one.
+func OnObservableCreate(hook CreateHook) {
+	updateHooks(func(set *hookSet) {
+		set.create = append(set.create[:len(set.create):len(set.create)], hook)
+	})
+}
+
+// OnSubscribe adds a hook called with the Observer of every Subscribe.
+func OnSubscribe(hook SubscribeHook) {
+	updateHooks(func(set *hookSet) {
+		set.subscribe = append(set.subscribe[:len(set.subscribe):len(set.subscribe)], hook)
+	})
+}
+
+// OnSubscribeTo adds a hook called with the Observer of every Subscribe to
+// o, after the hooks added with OnSubscribe, and returns o. The hook runs
+// in the subscription itself, without adding a stage to the pipeline. It
+// is dropped once o completes.
+func OnSubscribeTo(o Observable, hook SubscribeHook) Observable {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	var set []SubscribeHook
+	if v, ok := observableHooks.Load(o); ok {
+		set = v.([]SubscribeHook)
+	}
+	observableHooks.Store(o, append(set[:len(set):len(set)], hook))
+	return o
+}
+
+// OnNextHook adds a hook called with every item delivered by Subscribe.
+func OnNextHook(hook NextHook) {
+	updateHooks(func(set *hookSet) {
+		set.next = append(set.next[:len(set.next):len(set.next)], hook)
+	})
+}
+
+// OnErrorHook adds a hook called with every error delivered by Subscribe.
+func OnErrorHook(hook ErrorHook) {
+	updateHooks(func(set *hookSet) {
+		set.err = append(set.err[:len(set.err):len(set.err)], hook)
+	})
+}
+
+// ResetHooks removes every hook.
+func ResetHooks() {
+	updateHooks(func(set *hookSet) {
+		*set = hookSet{}
+	})
+	observableHooks.Range(func(o, _ interface{}) bool {
+		observableHooks.Delete(o)
+		return true
+	})
+}
+
+// created runs the create hooks on a new Observable built by the source
+// called name.
+func created(name string, o Observable) Observable {
+	for _, hook := range currentHooks().create {
+		o = hook(o)
+	}
+	return describe(o, name, nil)
+}
+
+func (set *hookSet) onSubscribe(ob observer.Observer) observer.Observer {
+	for _, hook := range set.subscribe {
+		ob = hook(ob)
+	}
+	return ob
+}
+
+// subscribedTo runs the hooks added to o with OnSubscribeTo.
+func subscribedTo(o Observable, ob observer.Observer) observer.Observer {
+	if v, ok := observableHooks.Load(o); ok {
+		for _, hook := range v.([]SubscribeHook) {
+			ob = hook(ob)
+		}
+	}
+	return ob
+}
+
+func (set *hookSet) onNext(item interface{}) interface{} {
+	for _, hook := range set.next {
+		item = hook(item)
+		if _, ok := item.(error); ok {
+			break
+		}
+	}
+	return item
+}
+
+func (set *hookSet) onError(err error) error {
+	for _, hook := range set.err {
+		err = hook(err)
+	}
+	return err
+}
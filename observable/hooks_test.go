@@ -0,0 +1,128 @@
+package observable
+
+import (
+	"bytes"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observer"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestOnObservableCreate(t *testing.T) {
+	defer ResetHooks()
+	var count int32
+	OnObservableCreate(func(o Observable) Observable {
+		atomic.AddInt32(&count, 1)
+		return o
+	})
+	OnObservableCreate(func(o Observable) Observable {
+		return o.Map(func(item interface{}) interface{} { return item.(int) * 10 })
+	})
+
+	items, err := Just(1, 2).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{10, 20}, items)
+	Range(0, 1)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&count))
+
+	ResetHooks()
+	items, _ = Just(1).ToSlice()
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestOnObservableCreateSources(t *testing.T) {
+	defer ResetHooks()
+	var count int32
+	OnObservableCreate(func(o Observable) Observable {
+		atomic.AddInt32(&count, 1)
+		return o
+	})
+
+	var recorded bytes.Buffer
+	<-Just(1).Record(&recorded).Subscribe(handlers.NextFunc(func(interface{}) {}))
+	atomic.StoreInt32(&count, 0)
+
+	items, err := ReplayRecording(&recorded, 0).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+	ch := make(chan interface{})
+	close(ch)
+	FromEventSource(ch, Block)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&count))
+}
+
+func TestSubscribeHooks(t *testing.T) {
+	defer ResetHooks()
+	var subscribed, done int32
+	OnSubscribe(func(ob observer.Observer) observer.Observer {
+		atomic.AddInt32(&subscribed, 1)
+		onDone := ob.DoneHandler
+		ob.DoneHandler = func() {
+			atomic.AddInt32(&done, 1)
+			if onDone != nil {
+				onDone()
+			}
+		}
+		return ob
+	})
+	// Chaos injection: fail on a given item.
+	OnNextHook(func(item interface{}) interface{} {
+		if item == 3 {
+			return errors.New("injected")
+		}
+		return item
+	})
+	OnErrorHook(func(err error) error {
+		return errors.New("hooked: " + err.Error())
+	})
+
+	var items []interface{}
+	var failure error
+	sub := <-Range(1, 5).SubscribeAll(
+		func(item interface{}) { items = append(items, item) },
+		func(err error) { failure = err },
+		nil,
+	)
+	assert.Equal(t, []interface{}{1, 2}, items)
+	assert.EqualError(t, failure, "hooked: injected")
+	assert.EqualError(t, sub.Err(), "hooked: injected")
+	assert.EqualValues(t, 1, atomic.LoadInt32(&subscribed))
+	assert.EqualValues(t, 0, atomic.LoadInt32(&done))
+
+	<-Just(1).Subscribe(handlers.NextFunc(func(interface{}) {}))
+	assert.EqualValues(t, 1, atomic.LoadInt32(&done))
+}
+
+func TestOnSubscribeTo(t *testing.T) {
+	defer ResetHooks()
+	var count int32
+	counted := func(ob observer.Observer) observer.Observer {
+		onNext := ob.NextHandler
+		ob.NextHandler = func(item interface{}) {
+			atomic.AddInt32(&count, 1)
+			onNext(item)
+		}
+		return ob
+	}
+
+	o := OnSubscribeTo(Just(1, 2), counted)
+	var items []interface{}
+	<-o.SubscribeFunc(func(item interface{}) { items = append(items, item) })
+	assert.Equal(t, []interface{}{1, 2}, items)
+	assert.EqualValues(t, 2, atomic.LoadInt32(&count))
+
+	// Other Observables aren't hooked.
+	<-Just(3).SubscribeFunc(func(interface{}) {})
+	assert.EqualValues(t, 2, atomic.LoadInt32(&count))
+	_, ok := observableHooks.Load(o)
+	assert.False(t, ok)
+
+	// Nor are they kept once an Observable fails.
+	o = OnSubscribeTo(Throw(errors.New("bang")), counted)
+	<-o.SubscribeFunc(func(interface{}) {})
+	_, ok = observableHooks.Load(o)
+	assert.False(t, ok)
+}
@@ -0,0 +1,292 @@
+package observable
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"runtime/debug"
+	"sort"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// InspectState is the lifecycle state of an inspected Observable.
+type InspectState uint32
+
+const (
+	// InspectActive means the Observable hasn't terminated yet.
+	InspectActive InspectState = iota
+	// InspectCompleted means the Observable completed.
+	InspectCompleted
+	// InspectFailed means the Observable emitted an error.
+	InspectFailed
+)
+
+func (s InspectState) String() string {
+	switch s {
+	case InspectActive:
+		return "active"
+	case InspectCompleted:
+		return "completed"
+	case InspectFailed:
+		return "failed"
+	default:
+		return "InspectState(" + strconv.Itoa(int(s)) + ")"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler.
+func (s InspectState) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (s *InspectState) UnmarshalText(text []byte) error {
+	for _, state := range []InspectState{InspectActive, InspectCompleted, InspectFailed} {
+		if state.String() == string(text) {
+			*s = state
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown InspectState %q", text)
+}
+
+// StageDepth is the number of items buffered by a stage of a pipeline.
+type StageDepth struct {
+	Name     string `json:"name"`
+	Pending  int    `json:"pending"`
+	Capacity int    `json:"capacity"`
+}
+
+// Inspection is a snapshot of the runtime stats of an inspected Observable.
+type Inspection struct {
+	Name        string       `json:"name"`
+	Subscribers int          `json:"subscribers"`
+	Emitted     uint64       `json:"emitted"`
+	LastEvent   time.Time    `json:"last_event"`
+	State       InspectState `json:"state"`
+	Error       string       `json:"error,omitempty"`
+	// Stages lists the stages feeding the Observable, itself last. Stages
+	// before its direct source are only known while EnableTopology is on.
+	Stages []StageDepth `json:"stages"`
+	// Subscriptions lists the subscriptions made with Subscribe which are
+	// still running.
+	Subscriptions []ActiveSubscription `json:"subscriptions,omitempty"`
+	// Topology is the recorded graph of the pipeline, if any.
+	Topology  *Topology `json:"topology,omitempty"`
+	CreatedAt time.Time `json:"created_at"`
+	// Stack is the stack trace of the goroutine which called Inspected.
+	Stack string `json:"stack"`
+}
+
+// ActiveSubscription is a running subscription to an inspected Observable.
+type ActiveSubscription struct {
+	ID    uint64    `json:"id"`
+	Since time.Time `json:"since"`
+}
+
+// inspector holds the stats of an Observable returned by Inspected.
+type inspector struct {
+	name      string
+	source    Observable
+	createdAt time.Time
+	stack     string
+
+	mu            sync.Mutex
+	emitted       uint64
+	lastEvent     time.Time
+	state         InspectState
+	err           error
+	subscriptions map[uint64]time.Time
+}
+
+var subscriptionIDs uint64
+
+var inspectors sync.Map
+
+func (in *inspector) record(item interface{}) {
+	in.mu.Lock()
+	in.lastEvent = time.Now()
+	if err, isErr := item.(error); isErr {
+		in.state = InspectFailed
+		in.err = err
+	} else {
+		in.emitted++
+	}
+	in.mu.Unlock()
+}
+
+func (in *inspector) complete() {
+	in.mu.Lock()
+	if in.state == InspectActive {
+		in.state = InspectCompleted
+	}
+	in.mu.Unlock()
+}
+
+func (in *inspector) inspect(o Observable) Inspection {
+	in.mu.Lock()
+	i := Inspection{
+		Name:        in.name,
+		Subscribers: len(in.subscriptions),
+		Emitted:     in.emitted,
+		LastEvent:   in.lastEvent,
+		State:       in.state,
+		CreatedAt:   in.createdAt,
+		Stack:       in.stack,
+	}
+	if in.err != nil {
+		i.Error = in.err.Error()
+	}
+	for id, since := range in.subscriptions {
+		i.Subscriptions = append(i.Subscriptions, ActiveSubscription{ID: id, Since: since})
+	}
+	in.mu.Unlock()
+	sort.Slice(i.Subscriptions, func(a, b int) bool { return i.Subscriptions[a].ID < i.Subscriptions[b].ID })
+
+	if topology := o.Topology(); len(topology.Nodes) > 1 {
+		i.Topology = &topology
+	}
+
+	// Walk the pipeline back along first inputs, as far as recorded.
+	var stages []StageDepth
+	for s := in.source; ; {
+		v, recorded := topologyRegistry.Load(s)
+		name := "Observable"
+		if recorded {
+			name = v.(topologyEntry).name
+		}
+		stages = append([]StageDepth{{Name: name, Pending: len(s), Capacity: cap(s)}}, stages...)
+		if !recorded || len(v.(topologyEntry).inputs) == 0 {
+			break
+		}
+		s = v.(topologyEntry).inputs[0]
+	}
+	i.Stages = append(stages, StageDepth{Name: in.name, Pending: len(o), Capacity: cap(o)})
+	return i
+}
+
+// Inspected returns an Observable emitting the items of the original one
+// and keeping runtime stats about them under name, which Inspect and
+// InspectHandler report. Terminated Observables are kept, so that their
+// final state can be inspected, until PruneInspected.
+func (o Observable) Inspected(name string) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	in := &inspector{
+		name:          name,
+		source:        o,
+		createdAt:     time.Now(),
+		stack:         string(debug.Stack()),
+		subscriptions: make(map[uint64]time.Time),
+	}
+	inspectors.Store(Observable(out), in)
+	go func() {
+		for item := range o {
+			in.record(item)
+			out <- item
+		}
+		in.complete()
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Inspected", map[string]interface{}{"name": name}, o)
+}
+
+// Inspect returns a snapshot of the runtime stats of an Observable returned
+// by Inspected, or false for any other Observable.
+func (o Observable) Inspect() (Inspection, bool) {
+	v, ok := inspectors.Load(o)
+	if !ok {
+		return Inspection{}, false
+	}
+	return v.(*inspector).inspect(o), true
+}
+
+// Inspections returns a snapshot of every inspected Observable, sorted by
+// name.
+func Inspections() []Inspection {
+	var all []Inspection
+	inspectors.Range(func(key, value interface{}) bool {
+		all = append(all, value.(*inspector).inspect(key.(Observable)))
+		return true
+	})
+	sort.SliceStable(all, func(i, j int) bool { return all[i].Name < all[j].Name })
+	return all
+}
+
+// PruneInspected forgets the inspected Observables which have terminated.
+func PruneInspected() {
+	inspectors.Range(func(key, value interface{}) bool {
+		in := value.(*inspector)
+		in.mu.Lock()
+		terminated := in.state != InspectActive
+		in.mu.Unlock()
+		if terminated {
+			inspectors.Delete(key)
+		}
+		return true
+	})
+}
+
+// subscribed tracks a subscriber of o if o is inspected, and returns a func
+// to call once it's gone.
+func subscribed(o Observable) func() {
+	v, ok := inspectors.Load(o)
+	if !ok {
+		return func() {}
+	}
+	in := v.(*inspector)
+	id := atomic.AddUint64(&subscriptionIDs, 1)
+	in.mu.Lock()
+	in.subscriptions[id] = time.Now()
+	in.mu.Unlock()
+	return func() {
+		in.mu.Lock()
+		delete(in.subscriptions, id)
+		in.mu.Unlock()
+	}
+}
+
+// InspectHandler returns an http.Handler serving the Inspections as JSON,
+// in the manner of expvar. The name and state query parameters filter them,
+// for instance ?state=active lists the Observables which haven't
+// terminated. With ?format=dot and a name, it serves the topology of that
+// Observable in the DOT language instead.
+func InspectHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name, state := r.FormValue("name"), r.FormValue("state")
+		inspections := []Inspection{}
+		for _, i := range Inspections() {
+			if (name == "" || i.Name == name) && (state == "" || i.State.String() == state) {
+				inspections = append(inspections, i)
+			}
+		}
+
+		if r.FormValue("format") == "dot" {
+			if name == "" || len(inspections) == 0 || inspections[0].Topology == nil {
+				http.Error(w, "no topology recorded for "+strconv.Quote(name), http.StatusNotFound)
+				return
+			}
+			w.Header().Set("Content-Type", "text/vnd.graphviz")
+			io.WriteString(w, inspections[0].Topology.DOT())
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(inspections)
+	})
+}
+
+// DebugPath is the path HandleDebug serves InspectHandler on.
+const DebugPath = "/debug/rxgo"
+
+// HandleDebug serves InspectHandler on DebugPath of mux, or of
+// http.DefaultServeMux when mux is nil.
+func HandleDebug(mux *http.ServeMux) {
+	if mux == nil {
+		mux = http.DefaultServeMux
+	}
+	mux.Handle(DebugPath, InspectHandler())
+}
@@ -0,0 +1,159 @@
+package observable
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInspect(t *testing.T) {
+	defer PruneInspected()
+	source := make(chan interface{}, 4)
+	o := Observable(source).Inspected("orders")
+
+	i, ok := o.Inspect()
+	assert.True(t, ok)
+	assert.Equal(t, "orders", i.Name)
+	assert.Equal(t, InspectActive, i.State)
+	assert.True(t, i.LastEvent.IsZero())
+
+	source <- 1
+	source <- 2
+	source <- 3
+	assert.Equal(t, 1, <-o)
+	// The second item is now pending on the output of Inspected.
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(time.Millisecond) {
+		if i, _ = o.Inspect(); i.Emitted == 2 {
+			break
+		}
+	}
+	assert.Equal(t, uint64(2), i.Emitted)
+	assert.False(t, i.LastEvent.IsZero())
+	assert.Equal(t, []StageDepth{
+		{Name: "Observable", Pending: 1, Capacity: 4},
+		{Name: "orders"},
+	}, i.Stages)
+
+	source <- errors.New("bang")
+	close(source)
+	items, err := o.ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{2, 3}, items)
+	for range o {
+	}
+	i, _ = o.Inspect()
+	assert.Equal(t, InspectFailed, i.State)
+	assert.Equal(t, "bang", i.Error)
+	assert.Equal(t, uint64(3), i.Emitted)
+
+	_, ok = Just(1).Inspect()
+	assert.False(t, ok)
+}
+
+func TestInspectSubscribers(t *testing.T) {
+	defer PruneInspected()
+	release := make(chan interface{})
+	o := Observable(release).Inspected("subscribed")
+	sub := o.Subscribe(handlers.NextFunc(func(interface{}) {}))
+	i, _ := o.Inspect()
+	assert.Equal(t, 1, i.Subscribers)
+
+	close(release)
+	<-sub
+	i, _ = o.Inspect()
+	assert.Equal(t, 0, i.Subscribers)
+	assert.Equal(t, InspectCompleted, i.State)
+}
+
+func TestInspectStages(t *testing.T) {
+	EnableTopology(true)
+	defer func() {
+		EnableTopology(false)
+		ResetTopology()
+		PruneInspected()
+	}()
+
+	o := Range(0, 3, WithBufferSize(8)).Map(increment).Inspected("numbers")
+	i, _ := o.Inspect()
+	names := []string{}
+	for _, s := range i.Stages {
+		names = append(names, s.Name)
+	}
+	assert.Equal(t, []string{"Range", "Map", "numbers"}, names)
+	assert.Equal(t, 8, i.Stages[0].Capacity)
+	o.ToSlice()
+}
+
+func TestInspectHandler(t *testing.T) {
+	defer PruneInspected()
+	o := Just(1).Inspected("handled")
+	o.ToSlice()
+	for range o {
+	}
+
+	rec := httptest.NewRecorder()
+	InspectHandler().ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+	assert.Equal(t, "application/json", rec.Header().Get("Content-Type"))
+
+	var inspections []map[string]interface{}
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &inspections))
+	assert.Len(t, inspections, 1)
+	assert.Equal(t, "handled", inspections[0]["name"])
+	assert.Equal(t, "completed", inspections[0]["state"])
+	assert.Equal(t, 1.0, inspections[0]["emitted"])
+
+	PruneInspected()
+	assert.Empty(t, Inspections())
+}
+
+func TestInspectDebugInfo(t *testing.T) {
+	EnableTopology(true)
+	defer func() {
+		EnableTopology(false)
+		ResetTopology()
+		PruneInspected()
+	}()
+
+	release := make(chan interface{})
+	o := Observable(release).Map(increment).Inspected("wedged")
+	o.Subscribe(handlers.NextFunc(func(interface{}) {}))
+
+	i, _ := o.Inspect()
+	assert.Len(t, i.Subscriptions, 1)
+	assert.False(t, i.Subscriptions[0].Since.IsZero())
+	assert.False(t, i.CreatedAt.IsZero())
+	assert.Contains(t, i.Stack, "TestInspectDebugInfo")
+	assert.Len(t, i.Topology.Nodes, 3)
+
+	mux := http.NewServeMux()
+	HandleDebug(mux)
+
+	rec := httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", DebugPath+"?state=active&name=wedged", nil))
+	var inspections []Inspection
+	assert.Nil(t, json.Unmarshal(rec.Body.Bytes(), &inspections))
+	assert.Len(t, inspections, 1)
+	assert.Len(t, inspections[0].Subscriptions, 1)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", DebugPath+"?name=wedged&format=dot", nil))
+	assert.Equal(t, "text/vnd.graphviz", rec.Header().Get("Content-Type"))
+	assert.Contains(t, rec.Body.String(), `n2 [label="Inspected\nname=wedged"];`)
+
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", DebugPath+"?name=missing&format=dot", nil))
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	close(release)
+	for range o {
+	}
+	rec = httptest.NewRecorder()
+	mux.ServeHTTP(rec, httptest.NewRequest("GET", DebugPath+"?state=active", nil))
+	assert.Equal(t, "[]\n", rec.Body.String())
+}
@@ -0,0 +1,101 @@
+package observable
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// joinSide is the buffered state of one of the Observables of a Join.
+type joinSide struct {
+	in     Observable
+	items  []timedItem
+	latest time.Time
+}
+
+// prune forgets the items too old to match any later item of the other
+// side, assuming each side's timestamps are ordered.
+func (s *joinSide) prune(other *joinSide, window time.Duration) {
+	horizon := other.latest.Add(-window)
+	n := 0
+	for n < len(s.items) && s.items[n].at.Before(horizon) {
+		n++
+	}
+	s.items = s.items[n:]
+}
+
+// Join correlates the items of the original Observable with those of other
+// whose times are within window of each other, emitting combine(left, right)
+// for every such pair as soon as its second item arrives. Times are the
+// arrival times of the items, or the event times returned by an optional
+// timestamp func, which is applied to the items of both Observables.
+//
+// Only the items which may still match are retained, which assumes that the
+// times of each Observable are increasing. Join completes once both
+// Observables have completed, or on the first error of either.
+func (o Observable) Join(other Observable, window time.Duration, combine fx.CombinableFunc, timestamp ...fx.TimestampFunc) Observable {
+	at := func(interface{}) time.Time { return time.Now() }
+	if len(timestamp) > 0 {
+		at = timestamp[0]
+	}
+
+	out := make(chan interface{})
+	register(out, o, other)
+	go func() {
+		defer closeStage(out)
+		left := &joinSide{in: o}
+		right := &joinSide{in: other}
+
+		// receive handles an item from side, reporting whether Join must
+		// terminate.
+		receive := func(side, opposite *joinSide, item interface{}) bool {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				return true
+			}
+			t := at(item)
+			for _, candidate := range opposite.items {
+				if d := t.Sub(candidate.at); d > window || d < -window {
+					continue
+				}
+				if side == left {
+					out <- combine(item, candidate.item)
+				} else {
+					out <- combine(candidate.item, item)
+				}
+			}
+			if t.After(side.latest) {
+				side.latest = t
+			}
+			if opposite.in != nil {
+				side.items = append(side.items, timedItem{t, item})
+			}
+			opposite.prune(side, window)
+			return false
+		}
+
+		for left.in != nil || right.in != nil {
+			select {
+			case item, ok := <-left.in:
+				if !ok {
+					left.in = nil
+					right.items = nil
+					continue
+				}
+				if receive(left, right, item) {
+					return
+				}
+			case item, ok := <-right.in:
+				if !ok {
+					right.in = nil
+					left.items = nil
+					continue
+				}
+				if receive(right, left, item) {
+					return
+				}
+			}
+		}
+	}()
+	return describe(Observable(out), "Join", nil, o, other)
+}
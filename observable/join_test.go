@@ -0,0 +1,41 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableJoin(t *testing.T) {
+	clicks := make(chan interface{})
+	impressions := make(chan interface{})
+	joined := FromChannel(clicks).Join(FromChannel(impressions), 5*time.Millisecond,
+		func(click, impression interface{}) interface{} {
+			return click.(event).value*10 + impression.(event).value
+		}, eventTimestamp)
+
+	go func() {
+		impressions <- event{0, 1}
+		impressions <- event{10, 2}
+		clicks <- event{3, 1}
+		clicks <- event{12, 2}
+		impressions <- event{14, 3}
+		clicks <- event{30, 3}
+		close(clicks)
+		impressions <- event{31, 4}
+		close(impressions)
+	}()
+
+	items, err := joined.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{11, 22, 23, 34}, items)
+}
+
+func TestObservableJoinError(t *testing.T) {
+	items, err := Just(1).Join(Just(errors.New("bang")), time.Second,
+		func(l, r interface{}) interface{} { return l }).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Empty(t, items)
+}
@@ -0,0 +1,23 @@
+package observable
+
+import (
+	"context"
+	"runtime/pprof"
+)
+
+// PprofLabel is the pprof label carrying the name given to Labeled.
+const PprofLabel = "rxgo.observable"
+
+// Labeled builds an Observable with build, and tags every goroutine spawned
+// while doing so, by Interval, Start or the operators, with a pprof label
+// set to name, so that CPU and goroutine profiles attribute their cost to
+// that stream. Additional labels are given as key-value pairs. Tasks run on
+// a Scheduler are tagged with scheduler.Labeled instead, as its goroutines
+// are shared between streams.
+func Labeled(name string, build func() Observable, labels ...string) Observable {
+	var o Observable
+	pprof.Do(context.Background(), pprof.Labels(append([]string{PprofLabel, name}, labels...)...), func(context.Context) {
+		o = build()
+	})
+	return o
+}
@@ -0,0 +1,26 @@
+package observable
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLabeled(t *testing.T) {
+	profile := make(chan string, 1)
+	o := Labeled("prices", func() Observable {
+		return Just(1).Map(func(item interface{}) interface{} {
+			var buf bytes.Buffer
+			pprof.Lookup("goroutine").WriteTo(&buf, 1)
+			profile <- buf.String()
+			return item
+		})
+	}, "team", "pricing")
+
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+	assert.Contains(t, <-profile, `"rxgo.observable":"prices"`)
+}
@@ -0,0 +1,99 @@
+package observable
+
+import (
+	"sync/atomic"
+
+	"github.com/reactivex/rxgo"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Lag accounts for the items of a subscription made with SubscribeTracked:
+// how many the producer emitted, how many reached the subscriber and how
+// many the backpressure strategy dropped. It is safe for concurrent use.
+type Lag struct {
+	produced  uint64
+	delivered uint64
+	dropped   uint64
+}
+
+// Produced returns the number of items emitted by the producer so far.
+func (l *Lag) Produced() uint64 {
+	return atomic.LoadUint64(&l.produced)
+}
+
+// Delivered returns the number of items delivered to the subscriber so far.
+func (l *Lag) Delivered() uint64 {
+	return atomic.LoadUint64(&l.delivered)
+}
+
+// Dropped returns the number of items dropped so far.
+func (l *Lag) Dropped() uint64 {
+	return atomic.LoadUint64(&l.dropped)
+}
+
+// Behind returns how many items the subscriber is behind the producer:
+// those emitted but neither delivered nor dropped yet.
+func (l *Lag) Behind() uint64 {
+	// Read in reverse order of the updates, so the result never underflows.
+	delivered, dropped := l.Delivered(), l.Dropped()
+	return l.Produced() - delivered - dropped
+}
+
+// SubscribeTracked subscribes handler through the buffer described by
+// strategy, like OnBackpressure followed by Subscribe, and returns a Lag
+// accounting for the items of the subscription while it runs. The final
+// Subscription reports the number of items dropped.
+func (o Observable) SubscribeTracked(handler rx.EventHandler, strategy BackpressureStrategy) (*Lag, <-chan subscription.Subscription) {
+	lag := new(Lag)
+	onDropped := strategy.onDropped
+	strategy.onDropped = func(item interface{}) {
+		if batch, ok := item.(Batch); ok {
+			atomic.AddUint64(&lag.dropped, uint64(len(batch)))
+		} else {
+			atomic.AddUint64(&lag.dropped, 1)
+		}
+		if onDropped != nil {
+			onDropped(item)
+		}
+	}
+
+	produced := make(chan interface{})
+	register(produced, o)
+	go func() {
+		for item := range o {
+			switch item := item.(type) {
+			case error:
+			case Batch:
+				atomic.AddUint64(&lag.produced, uint64(len(item)))
+			default:
+				atomic.AddUint64(&lag.produced, 1)
+			}
+			produced <- item
+		}
+		closeStage(produced)
+	}()
+
+	ob := CheckEventHandler(handler)
+	onNext, onBatch := ob.NextHandler, ob.BatchHandler
+	ob.NextHandler = func(item interface{}) {
+		atomic.AddUint64(&lag.delivered, 1)
+		if onNext != nil {
+			onNext(item)
+		}
+	}
+	if onBatch != nil {
+		ob.BatchHandler = func(items []interface{}) {
+			atomic.AddUint64(&lag.delivered, uint64(len(items)))
+			onBatch(items)
+		}
+	}
+
+	subscribed := Observable(produced).OnBackpressure(strategy).Subscribe(ob)
+	done := make(chan subscription.Subscription, 1)
+	go func() {
+		sub := <-subscribed
+		sub.Dropped = lag.Dropped()
+		done <- sub
+	}()
+	return lag, done
+}
@@ -0,0 +1,59 @@
+package observable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubscribeTracked(t *testing.T) {
+	var items []interface{}
+	lag, sub := Range(0, 5).SubscribeTracked(handlers.NextFunc(func(item interface{}) {
+		items = append(items, item)
+	}), Block)
+
+	s := <-sub
+	assert.Nil(t, s.Err())
+	assert.Equal(t, []interface{}{0, 1, 2, 3, 4}, items)
+	assert.Equal(t, uint64(5), lag.Produced())
+	assert.Equal(t, uint64(5), lag.Delivered())
+	assert.Equal(t, uint64(0), lag.Dropped())
+	assert.Equal(t, uint64(0), lag.Behind())
+	assert.Equal(t, uint64(0), s.Dropped)
+}
+
+func TestSubscribeTrackedDrops(t *testing.T) {
+	gate := make(chan struct{})
+	var dropped []interface{}
+	lag, sub := Range(0, 5).SubscribeTracked(handlers.NextFunc(func(item interface{}) {
+		<-gate
+	}), DropLatest.OnDropped(func(item interface{}) {
+		dropped = append(dropped, item)
+	}))
+
+	for deadline := time.Now().Add(time.Second); lag.Produced() < 5 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	close(gate)
+
+	s := <-sub
+	assert.Equal(t, uint64(5), lag.Produced())
+	assert.Equal(t, uint64(5), lag.Delivered()+lag.Dropped())
+	assert.True(t, lag.Dropped() > 0)
+	assert.Equal(t, lag.Dropped(), s.Dropped)
+	assert.Len(t, dropped, int(s.Dropped))
+	assert.Equal(t, uint64(0), lag.Behind())
+}
+
+func TestSubscribeTrackedBatches(t *testing.T) {
+	var batches int
+	lag, sub := Just(1, 2, 3).Batched(2).SubscribeTracked(handlers.BatchFunc(func([]interface{}) {
+		batches++
+	}), Block)
+	<-sub
+	assert.Equal(t, 2, batches)
+	assert.Equal(t, uint64(3), lag.Produced())
+	assert.Equal(t, uint64(3), lag.Delivered())
+}
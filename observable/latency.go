@@ -0,0 +1,81 @@
+package observable
+
+import (
+	"time"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// LatencyRecorder receives the latency measured by MeasureLatency for each
+// item reaching stage.
+type LatencyRecorder func(stage string, latency time.Duration)
+
+// LatencyOption configures MeasureLatency.
+type LatencyOption func(*latencyConfig)
+
+type latencyConfig struct {
+	start    fx.TimestampFunc
+	clock    Clock
+	recorder LatencyRecorder
+}
+
+// LatencySince makes MeasureLatency measure from the time start returns for
+// each item, such as its event timestamp, instead of the time of a
+// Timestamped item.
+func LatencySince(start fx.TimestampFunc) LatencyOption {
+	return func(c *latencyConfig) {
+		c.start = start
+	}
+}
+
+// LatencyClock makes MeasureLatency read the arrival time from clock.
+func LatencyClock(clock Clock) LatencyOption {
+	return func(c *latencyConfig) {
+		c.clock = clock
+	}
+}
+
+// LatencyTo sets the LatencyRecorder MeasureLatency reports to, for
+// instance metrics.LatencyRecorder.
+func LatencyTo(recorder LatencyRecorder) LatencyOption {
+	return func(c *latencyConfig) {
+		c.recorder = recorder
+	}
+}
+
+// timestampOf returns the time of a Timestamped item, the default start
+// marker of MeasureLatency.
+func timestampOf(item interface{}) time.Time {
+	if t, ok := item.(Timestamped); ok {
+		return t.Time
+	}
+	return time.Time{}
+}
+
+// MeasureLatency passes the items of the original Observable through
+// unchanged and reports, for each one, the time elapsed between its start
+// marker and its arrival at this stage. By default the start marker is the
+// time of a Timestamped item, so an upstream Timestamp marks where the
+// measurement begins; LatencySince measures from a time carried by the
+// items instead. Items without a start marker and errors aren't measured.
+func (o Observable) MeasureLatency(stage string, opts ...LatencyOption) Observable {
+	config := latencyConfig{start: timestampOf, clock: SystemClock}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); !isErr && config.recorder != nil {
+				if start := config.start(item); !start.IsZero() {
+					config.recorder(stage, config.clock.Now().Sub(start))
+				}
+			}
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "MeasureLatency", map[string]interface{}{"stage": stage}, o)
+}
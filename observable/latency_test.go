@@ -0,0 +1,51 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMeasureLatency(t *testing.T) {
+	now := time.Unix(100, 0)
+	var stages []string
+	var latencies []time.Duration
+
+	items, err := Just(
+		Timestamped{Value: 1, Time: now.Add(-5 * time.Millisecond)},
+		Timestamped{Value: 2, Time: now.Add(-20 * time.Millisecond)},
+		3,
+		errors.New("bang"),
+	).MeasureLatency("mapped",
+		LatencyClock(NewManualClock(now)),
+		LatencyTo(func(stage string, latency time.Duration) {
+			stages = append(stages, stage)
+			latencies = append(latencies, latency)
+		}),
+	).ToSlice()
+
+	assert.EqualError(t, err, "bang")
+	assert.Len(t, items, 3)
+	assert.Equal(t, []string{"mapped", "mapped"}, stages)
+	assert.Equal(t, []time.Duration{5 * time.Millisecond, 20 * time.Millisecond}, latencies)
+}
+
+func TestMeasureLatencySince(t *testing.T) {
+	var latencies []time.Duration
+	sentAt := func(item interface{}) time.Time {
+		return time.Unix(int64(item.(int)), 0)
+	}
+	items, err := Just(97, 99).MeasureLatency("ingest",
+		LatencySince(sentAt),
+		LatencyClock(NewManualClock(time.Unix(100, 0))),
+		LatencyTo(func(stage string, latency time.Duration) {
+			latencies = append(latencies, latency)
+		}),
+	).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{97, 99}, items)
+	assert.Equal(t, []time.Duration{3 * time.Second, time.Second}, latencies)
+}
@@ -0,0 +1,128 @@
+//go:build go1.21
+
+package observable
+
+import (
+	"context"
+	"log/slog"
+	"sync/atomic"
+)
+
+// LogLevels sets the level at which Log reports each DebugSignal.
+type LogLevels struct {
+	Subscribe slog.Level
+	Next      slog.Level
+	Error     slog.Level
+	Complete  slog.Level
+}
+
+// DefaultLogLevels reports items at debug level, subscription and
+// completion at info level and errors at error level.
+var DefaultLogLevels = LogLevels{
+	Subscribe: slog.LevelInfo,
+	Next:      slog.LevelDebug,
+	Error:     slog.LevelError,
+	Complete:  slog.LevelInfo,
+}
+
+func (l LogLevels) of(signal DebugSignal) slog.Level {
+	switch signal {
+	case DebugSubscribe:
+		return l.Subscribe
+	case DebugNext:
+		return l.Next
+	case DebugError:
+		return l.Error
+	default:
+		return l.Complete
+	}
+}
+
+// LogOption configures Log.
+type LogOption func(*logConfig)
+
+type logConfig struct {
+	logger *slog.Logger
+	levels LogLevels
+}
+
+// LogWith makes Log write to logger instead of the default one.
+func LogWith(logger *slog.Logger) LogOption {
+	return func(c *logConfig) {
+		c.logger = logger
+	}
+}
+
+// LogLevelsOf makes Log report signals at the given levels.
+func LogLevelsOf(levels LogLevels) LogOption {
+	return func(c *logConfig) {
+		c.levels = levels
+	}
+}
+
+var defaultLogger atomic.Pointer[slog.Logger]
+
+// SetDefaultLogger sets the logger used by Log when none is given. Until
+// it is called, slog.Default is used.
+func SetDefaultLogger(logger *slog.Logger) {
+	defaultLogger.Store(logger)
+}
+
+// DefaultLogger returns the logger used by Log when none is given.
+func DefaultLogger() *slog.Logger {
+	if logger := defaultLogger.Load(); logger != nil {
+		return logger
+	}
+	return slog.Default()
+}
+
+// Log passes the items of the original Observable through unchanged while
+// logging every signal with a structured logger, tagged with an
+// "observable" attribute set to name. Signals below the level enabled on
+// the logger cost a single check.
+func (o Observable) Log(name string, opts ...LogOption) Observable {
+	config := logConfig{levels: DefaultLogLevels}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	if config.logger == nil {
+		config.logger = DefaultLogger()
+	}
+	logger := config.logger.With(slog.String("observable", name))
+	ctx := context.Background()
+	report := func(signal DebugSignal, item interface{}) {
+		level := config.levels.of(signal)
+		if !logger.Enabled(ctx, level) {
+			return
+		}
+		switch signal {
+		case DebugNext:
+			logger.Log(ctx, level, signal.String(), slog.Any("item", item))
+		case DebugError:
+			logger.Log(ctx, level, signal.String(), slog.Any("error", item))
+		default:
+			logger.Log(ctx, level, signal.String())
+		}
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		report(DebugSubscribe, nil)
+		failed := false
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				failed = true
+				report(DebugError, item)
+			} else {
+				report(DebugNext, item)
+			}
+			out <- item
+		}
+		if !failed {
+			report(DebugComplete, nil)
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Log", map[string]interface{}{"name": name}, o)
+}
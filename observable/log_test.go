@@ -0,0 +1,47 @@
+//go:build go1.21
+
+package observable
+
+import (
+	"bytes"
+	"errors"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func removeTime(groups []string, a slog.Attr) slog.Attr {
+	if a.Key == slog.TimeKey {
+		return slog.Attr{}
+	}
+	return a
+}
+
+func TestLog(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug, ReplaceAttr: removeTime}))
+
+	items, err := Just(1, 2).Log("numbers", LogWith(logger)).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2}, items)
+	// Completion is logged before the output is closed.
+	assert.Equal(t, `level=INFO msg=subscribe observable=numbers
+level=DEBUG msg=next observable=numbers item=1
+level=DEBUG msg=next observable=numbers item=2
+level=INFO msg=complete observable=numbers
+`, buf.String())
+}
+
+func TestLogLevels(t *testing.T) {
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{ReplaceAttr: removeTime}))
+	SetDefaultLogger(logger)
+	defer SetDefaultLogger(nil)
+	assert.Equal(t, logger, DefaultLogger())
+
+	levels := DefaultLogLevels
+	levels.Subscribe = slog.LevelDebug
+	Just(1, errors.New("bang")).Log("failing", LogLevelsOf(levels)).ToSlice()
+	assert.Equal(t, "level=ERROR msg=error observable=failing error=bang\n", buf.String())
+}
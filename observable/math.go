@@ -0,0 +1,356 @@
+package observable
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/fx"
+)
+
+// numeric is a number emitted by an Observable, widened to the largest of the
+// int, int64 and float64 types seen so far.
+type numeric struct {
+	kind    int // 0: int, 1: int64, 2: float64
+	integer int64
+	float   float64
+}
+
+func toNumeric(item interface{}) (numeric, error) {
+	switch item := item.(type) {
+	case int:
+		return numeric{kind: 0, integer: int64(item), float: float64(item)}, nil
+	case int64:
+		return numeric{kind: 1, integer: item, float: float64(item)}, nil
+	case float64:
+		return numeric{kind: 2, float: item}, nil
+	default:
+		return numeric{}, errors.New(errors.ObservableError, fmt.Sprintf("%v is not an int, int64 or float64", item))
+	}
+}
+
+func (n numeric) add(m numeric) numeric {
+	if m.kind > n.kind {
+		n.kind = m.kind
+	}
+	n.integer += m.integer
+	n.float += m.float
+	return n
+}
+
+func (n numeric) less(m numeric) bool {
+	if n.kind == 2 || m.kind == 2 {
+		return n.float < m.float
+	}
+	return n.integer < m.integer
+}
+
+func (n numeric) value() interface{} {
+	switch n.kind {
+	case 0:
+		return int(n.integer)
+	case 1:
+		return n.integer
+	default:
+		return n.float
+	}
+}
+
+// aggregate emits the result of folding every number in the original
+// Observable with fold, or nothing if it is empty, and an ObservableError
+// for any item which isn't an int, int64 or float64.
+func (o Observable) aggregate(fold func(acc, n numeric, count int) numeric, result func(acc numeric, count int) interface{}) Observable {
+	out := make(chan interface{}, 1)
+	register(out, o)
+	go func() {
+		var acc numeric
+		count := 0
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				closeStage(out)
+				return
+			}
+			n, err := toNumeric(item)
+			if err != nil {
+				out <- err
+				closeStage(out)
+				return
+			}
+			count++
+			if count == 1 {
+				acc = n
+			} else {
+				acc = fold(acc, n, count)
+			}
+		}
+		if count > 0 {
+			out <- result(acc, count)
+		}
+		closeStage(out)
+	}()
+	return Observable(out)
+}
+
+// Count emits the number of items in the original Observable once it completes.
+func (o Observable) Count() Observable {
+	out := make(chan interface{}, 1)
+	register(out, o)
+	go func() {
+		count := 0
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				closeStage(out)
+				return
+			}
+			count++
+		}
+		out <- count
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Count", nil, o)
+}
+
+// Sum emits the sum of the numbers in the original Observable once it
+// completes. The sum is an int if every number is an int, an int64 if there
+// are int64s but no float64s, and a float64 otherwise. An empty Observable
+// sums to the int 0.
+func (o Observable) Sum() Observable {
+	return describe(o.aggregate(func(acc, n numeric, _ int) numeric {
+		return acc.add(n)
+	}, func(acc numeric, _ int) interface{} {
+		return acc.value()
+	}).DefaultIfEmpty(0), "Sum", nil, o)
+}
+
+// Average emits the float64 mean of the numbers in the original Observable
+// once it completes, or nothing if it is empty.
+func (o Observable) Average() Observable {
+	return describe(o.aggregate(func(acc, n numeric, _ int) numeric {
+		return acc.add(n)
+	}, func(acc numeric, count int) interface{} {
+		return acc.float / float64(count)
+	}), "Average", nil, o)
+}
+
+// Min emits the smallest number in the original Observable once it completes,
+// or nothing if it is empty.
+func (o Observable) Min() Observable {
+	return describe(o.aggregate(func(acc, n numeric, _ int) numeric {
+		if n.less(acc) {
+			return n
+		}
+		return acc
+	}, func(acc numeric, _ int) interface{} {
+		return acc.value()
+	}), "Min", nil, o)
+}
+
+// Max emits the largest number in the original Observable once it completes,
+// or nothing if it is empty.
+func (o Observable) Max() Observable {
+	return describe(o.aggregate(func(acc, n numeric, _ int) numeric {
+		if acc.less(n) {
+			return n
+		}
+		return acc
+	}, func(acc numeric, _ int) interface{} {
+		return acc.value()
+	}), "Max", nil, o)
+}
+
+// Stats is a snapshot of running statistics over a stream of numbers.
+type Stats struct {
+	Count    int
+	Mean     float64
+	Variance float64 // population variance
+	Min      float64
+	Max      float64
+}
+
+// add folds x into the statistics using Welford's algorithm, returning the
+// updated sum of squared deviations m2.
+func (s *Stats) add(x, m2 float64) float64 {
+	s.Count++
+	if s.Count == 1 || x < s.Min {
+		s.Min = x
+	}
+	if s.Count == 1 || x > s.Max {
+		s.Max = x
+	}
+	delta := x - s.Mean
+	s.Mean += delta / float64(s.Count)
+	m2 += delta * (x - s.Mean)
+	s.Variance = m2 / float64(s.Count)
+	return m2
+}
+
+// Stats emits a running Stats snapshot over the numbers in the original
+// Observable, computed in constant memory. A snapshot is emitted after every
+// number, or after every n numbers if specified, and once more on completion
+// if numbers have arrived since the last one. Items which aren't an int, int64
+// or float64 terminate the Observable with an ObservableError.
+func (o Observable) Stats(every ...uint) Observable {
+	n := uint(1)
+	if len(every) > 0 && every[0] > 0 {
+		n = every[0]
+	}
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		var stats Stats
+		var m2 float64
+		pending := uint(0)
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				closeStage(out)
+				return
+			}
+			num, err := toNumeric(item)
+			if err != nil {
+				out <- err
+				closeStage(out)
+				return
+			}
+			m2 = stats.add(num.float, m2)
+			if pending++; pending == n {
+				out <- stats
+				pending = 0
+			}
+		}
+		if pending > 0 {
+			out <- stats
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Stats", nil, o)
+}
+
+// minHeap keeps the smallest item of a TopK selection at its root.
+type minHeap struct {
+	items []interface{}
+	less  fx.LessFunc
+}
+
+func (h *minHeap) Len() int              { return len(h.items) }
+func (h *minHeap) Less(i, j int) bool    { return h.less(h.items[i], h.items[j]) }
+func (h *minHeap) Swap(i, j int)         { h.items[i], h.items[j] = h.items[j], h.items[i] }
+func (h *minHeap) Push(item interface{}) { h.items = append(h.items, item) }
+func (h *minHeap) Pop() interface{} {
+	last := h.items[len(h.items)-1]
+	h.items = h.items[:len(h.items)-1]
+	return last
+}
+
+// TopK emits the k largest items of the original Observable according to
+// less, largest first, once it completes. Only k items are retained at any
+// time, so the whole stream is never collected.
+func (o Observable) TopK(k uint, less fx.LessFunc) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		h := &minHeap{less: less}
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				closeStage(out)
+				return
+			}
+			if uint(h.Len()) < k {
+				heap.Push(h, item)
+			} else if k > 0 && less(h.items[0], item) {
+				h.items[0] = item
+				heap.Fix(h, 0)
+			}
+		}
+		sort.SliceStable(h.items, func(i, j int) bool {
+			return less(h.items[j], h.items[i])
+		})
+		for _, item := range h.items {
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "TopK", map[string]interface{}{"k": k}, o)
+}
+
+// Histogram is a snapshot of the distribution of a stream of numbers over
+// a fixed set of buckets.
+type Histogram struct {
+	// Bounds are the sorted upper bounds of the buckets.
+	Bounds []float64
+	// Counts holds the number of values falling in each bucket: Counts[i]
+	// counts values v with Bounds[i-1] < v <= Bounds[i], and the final extra
+	// entry counts values above the last bound.
+	Counts []uint64
+	Count  uint64
+	Sum    float64
+}
+
+// Cumulative returns the cumulative bucket counts, the form expected by
+// Prometheus-style metrics backends.
+func (h Histogram) Cumulative() []uint64 {
+	cumulative := make([]uint64, len(h.Counts))
+	var total uint64
+	for i, c := range h.Counts {
+		total += c
+		cumulative[i] = total
+	}
+	return cumulative
+}
+
+// Histogram emits a Histogram of the numbers in the original Observable over
+// buckets with the given upper bounds once it completes. If every is
+// specified a snapshot is also emitted after every that many numbers. Items
+// which aren't an int, int64 or float64 terminate the Observable with an
+// ObservableError.
+func (o Observable) Histogram(buckets []float64, every ...uint) Observable {
+	bounds := append([]float64(nil), buckets...)
+	sort.Float64s(bounds)
+	var n uint
+	if len(every) > 0 {
+		n = every[0]
+	}
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		defer closeStage(out)
+		counts := make([]uint64, len(bounds)+1)
+		var count uint64
+		var sum float64
+		snapshot := func() Histogram {
+			return Histogram{
+				Bounds: bounds,
+				Counts: append([]uint64(nil), counts...),
+				Count:  count,
+				Sum:    sum,
+			}
+		}
+		pending := uint(0)
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				return
+			}
+			num, err := toNumeric(item)
+			if err != nil {
+				out <- err
+				return
+			}
+			counts[sort.SearchFloat64s(bounds, num.float)]++
+			count++
+			sum += num.float
+			if pending++; pending == n {
+				out <- snapshot()
+				pending = 0
+			}
+		}
+		if n == 0 || pending > 0 {
+			out <- snapshot()
+		}
+	}()
+	return describe(Observable(out), "Histogram", nil, o)
+}
@@ -0,0 +1,113 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableCount(t *testing.T) {
+	count, err := Range(0, 5).Count().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, 5, count)
+
+	count, err = Empty().Count().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, 0, count)
+
+	_, err = Just(1, errors.New("bang")).Count().BlockingSingle()
+	assert.EqualError(t, err, "bang")
+}
+
+func TestObservableSum(t *testing.T) {
+	sum, err := Range(0, 5).Sum().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, 10, sum)
+
+	sum, err = Just(1, int64(2)).Sum().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, int64(3), sum)
+
+	sum, err = Just(1, int64(2), 0.5).Sum().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, 3.5, sum)
+
+	sum, err = Empty().Sum().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, 0, sum)
+
+	_, err = Just(1, "two").Sum().BlockingSingle()
+	assert.Error(t, err)
+}
+
+func TestObservableAverage(t *testing.T) {
+	avg, err := Just(1, 2, 3, 4).Average().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, 2.5, avg)
+
+	items, err := Empty().Average().ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestObservableMinMax(t *testing.T) {
+	min, err := Just(3, 1.5, int64(-2), 7).Min().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, int64(-2), min)
+
+	max, err := Just(3, 1.5, int64(-2), 7).Max().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Exactly(t, 7, max)
+}
+
+func TestObservableStats(t *testing.T) {
+	last, err := Just(2, 4, 4, 4, 5, 5, 7, 9).Stats().BlockingLast()
+	assert.Nil(t, err)
+	assert.Equal(t, Stats{Count: 8, Mean: 5, Variance: 4, Min: 2, Max: 9}, last)
+
+	items, err := Range(0, 5).Stats(2).ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, 2, items[0].(Stats).Count)
+	assert.Equal(t, 4, items[1].(Stats).Count)
+	assert.Equal(t, 5, items[2].(Stats).Count)
+	assert.Equal(t, 2.0, items[2].(Stats).Mean)
+
+	_, err = Just(1, "x").Stats().ToSlice()
+	assert.Error(t, err)
+}
+
+func TestObservableTopK(t *testing.T) {
+	less := func(a, b interface{}) bool { return a.(int) < b.(int) }
+
+	items, err := Just(5, 1, 9, 3, 7, 2).TopK(3, less).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{9, 7, 5}, items)
+
+	items, err = Just(2, 1).TopK(5, less).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 1}, items)
+
+	items, err = Just(2, 1).TopK(0, less).ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestObservableHistogram(t *testing.T) {
+	h, err := Just(0.5, 1, 3, 7, 12).Histogram([]float64{10, 1, 5}).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, Histogram{
+		Bounds: []float64{1, 5, 10},
+		Counts: []uint64{2, 1, 1, 1},
+		Count:  5,
+		Sum:    23.5,
+	}, h)
+	assert.Equal(t, []uint64{2, 3, 4, 5}, h.(Histogram).Cumulative())
+
+	snapshots, err := Range(0, 5).Histogram([]float64{2}, 2).ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, snapshots, 3)
+	assert.Equal(t, []uint64{2, 0}, snapshots[0].(Histogram).Counts)
+	assert.Equal(t, []uint64{3, 2}, snapshots[2].(Histogram).Counts)
+}
@@ -0,0 +1,72 @@
+package observable
+
+// NotificationKind identifies the signal a Notification carries.
+type NotificationKind uint32
+
+const (
+	// NextNotification carries an item.
+	NextNotification NotificationKind = iota
+
+	// ErrorNotification carries the error which terminated a stream.
+	ErrorNotification
+
+	// DoneNotification marks the completion of a stream.
+	DoneNotification
+)
+
+// Notification represents a signal of a stream as a plain value.
+type Notification struct {
+	Kind NotificationKind
+	Item interface{}
+	Err  error
+}
+
+// Materialize turns every signal of the original Observable into a
+// Notification emitted as an item, ending with an ErrorNotification or a
+// DoneNotification. The new Observable itself never emits an error.
+func (o Observable) Materialize() Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				out <- Notification{Kind: ErrorNotification, Err: err}
+				closeStage(out)
+				return
+			}
+			out <- Notification{Kind: NextNotification, Item: item}
+		}
+		out <- Notification{Kind: DoneNotification}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Materialize", nil, o)
+}
+
+// Dematerialize turns the Notifications emitted by a materialized Observable
+// back into the signals they represent. Items which aren't Notifications are
+// emitted as they are.
+func (o Observable) Dematerialize() Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+	OuterLoop:
+		for item := range o {
+			notification, ok := item.(Notification)
+			if !ok {
+				out <- item
+				continue
+			}
+			switch notification.Kind {
+			case ErrorNotification:
+				out <- notification.Err
+				break OuterLoop
+			case DoneNotification:
+				break OuterLoop
+			default:
+				out <- notification.Item
+			}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Dematerialize", nil, o)
+}
@@ -0,0 +1,40 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableMaterialize(t *testing.T) {
+	items, err := Just(1, 2).Materialize().ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{
+		Notification{Kind: NextNotification, Item: 1},
+		Notification{Kind: NextNotification, Item: 2},
+		Notification{Kind: DoneNotification},
+	}, items)
+}
+
+func TestObservableMaterializeWithError(t *testing.T) {
+	bang := errors.New("bang")
+	items, err := Just(1, bang, 2).Materialize().ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{
+		Notification{Kind: NextNotification, Item: 1},
+		Notification{Kind: ErrorNotification, Err: bang},
+	}, items)
+}
+
+func TestObservableDematerialize(t *testing.T) {
+	items, err := Just(1, 2).Materialize().Dematerialize().ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{1, 2}, items)
+
+	items, err = Just(1, errors.New("bang")).Materialize().Dematerialize().ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Exactly(t, []interface{}{1}, items)
+}
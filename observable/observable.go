@@ -1,14 +1,39 @@
+// Package observable provides an Observable and its operators.
+//
+// Operators are methods returning a new Observable, so pipelines chain
+// fluently and end with a Subscribe or a blocking terminal operator:
+//
+//	sub := observable.Just(1, 2, 3).
+//		Map(double).
+//		Filter(isPositive).
+//		Subscribe(onNext)
+//	<-sub
+//
+// Each stage runs in its own goroutine and completes by closing its channel
+// once its source completes, except that adjacent synchronous operators, such
+// as Map, Filter and Take, are fused as they are chained, into a single
+// goroutine calling them in turn for each item. Channels only remain between
+// the other stages and at explicit boundaries such as ObserveOn. Teardown travels the other way: Unsubscribe on
+// the last Observable of a pipeline cancels every stage up to the sources,
+// which stop producing, and an operator which completes early, such as
+// Take, unsubscribes from its source in the same way. Observables built on
+// a channel of your own, as with FromChannel, are read to their end instead.
 package observable
 
 import (
+	"container/list"
+	"reflect"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/reactivex/rxgo"
 	"github.com/reactivex/rxgo/errors"
 	"github.com/reactivex/rxgo/fx"
 	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/iterable"
 	"github.com/reactivex/rxgo/observer"
+	"github.com/reactivex/rxgo/scheduler"
 	"github.com/reactivex/rxgo/subscription"
 )
 
@@ -32,6 +57,8 @@ func CheckEventHandler(handler rx.EventHandler) observer.Observer {
 		ob.ErrHandler = handler
 	case handlers.DoneFunc:
 		ob.DoneHandler = handler
+	case handlers.BatchFunc:
+		ob.BatchHandler = handler
 	case observer.Observer:
 		ob = handler
 	}
@@ -51,28 +78,58 @@ func (o Observable) Subscribe(handler rx.EventHandler) <-chan subscription.Subsc
 	done := make(chan subscription.Subscription)
 	sub := subscription.New().Subscribe()
 
-	ob := CheckEventHandler(handler)
+	hooks := currentHooks()
+	ob := subscribedTo(o, hooks.onSubscribe(CheckEventHandler(handler)))
+	unsubscribed := subscribed(o)
+	stopped := stageOf(o).cancelled()
 
 	go func() {
+		cancelled := false
 	OuterLoop:
-		for item := range o {
+		for {
+			var item interface{}
+			select {
+			case next, ok := <-o:
+				if !ok {
+					break OuterLoop
+				}
+				item = next
+				select {
+				case <-stopped:
+					cancelled = true
+					break OuterLoop
+				default:
+				}
+			case <-stopped:
+				cancelled = true
+				break OuterLoop
+			}
+			if _, isErr := item.(error); !isErr {
+				item = hooks.onNext(item)
+			}
 			switch item := item.(type) {
 			case error:
+				item = hooks.onError(item)
 				ob.OnError(item)
 
 				// Record the error and break the loop.
 				sub.Error = item
+				release(o)
 				break OuterLoop
+			case Batch:
+				ob.OnNextBatch(item)
 			default:
 				ob.OnNext(item)
 			}
 		}
 
-		// OnDone only gets executed if there's no error.
-		if sub.Error == nil {
+		// OnDone only gets executed if there's no error nor unsubscription.
+		if sub.Error == nil && !cancelled {
 			ob.OnDone()
 		}
+		observableHooks.Delete(o)
 
+		unsubscribed()
 		done <- sub.Unsubscribe()
 		return
 	}()
@@ -80,49 +137,144 @@ func (o Observable) Subscribe(handler rx.EventHandler) <-chan subscription.Subsc
 	return done
 }
 
-/*
-func (o Observable) Unsubscribe() subscription.Subscription {
-	// Stub: to be implemented
-	return subscription.New()
+// SubscribeFunc subscribes a plain func to the items of the Observable.
+func (o Observable) SubscribeFunc(onNext handlers.NextFunc) <-chan subscription.Subscription {
+	return o.SubscribeAll(onNext, nil, nil)
 }
-*/
 
-// Map maps a MappableFunc predicate to each item in Observable and
-// returns a new Observable with applied items.
-func (o Observable) Map(apply fx.MappableFunc) Observable {
+// SubscribeAll subscribes plain funcs to the items, the error and the
+// completion of the Observable, without building an Observer. Any of them
+// may be nil.
+func (o Observable) SubscribeAll(onNext handlers.NextFunc, onError handlers.ErrFunc, onDone handlers.DoneFunc) <-chan subscription.Subscription {
+	return o.Subscribe(observer.Observer{
+		NextHandler: onNext,
+		ErrHandler:  onError,
+		DoneHandler: onDone,
+	})
+}
+
+// Operator is a standalone transformation of an Observable, letting
+// user-defined and built-in operators compose uniformly and reusable
+// chains be stored as values.
+type Operator func(Observable) Observable
+
+// Compose chains operators into a single Operator applying them in order.
+func Compose(ops ...Operator) Operator {
+	return func(o Observable) Observable {
+		return o.Pipe(ops...)
+	}
+}
+
+// Pipe applies operators to the original Observable in order.
+func (o Observable) Pipe(ops ...Operator) Observable {
+	for _, op := range ops {
+		o = op(o)
+	}
+	return o
+}
+
+// Lift is the extension point for writing operators outside this package.
+// op receives the downstream Observer and returns the Observer which Lift
+// feeds with the items of the original Observable.
+//
+// The contract is as follows. The returned Observer's handlers are called
+// sequentially from a single goroutine: OnNext for each item, then either
+// OnError for an error or OnDone on completion. The operator may call the
+// downstream handlers any number of times from within those calls, and must
+// not retain them to call from other goroutines. Calling the downstream
+// OnError or OnDone terminates the lifted Observable: Lift stops feeding the
+// operator, ignores any further downstream calls and unsubscribes from the
+// original Observable, so an operator can cancel its source early.
+// Unsubscribing from the lifted Observable cancels the original one too.
+// Once the original Observable terminates the lifted Observable completes,
+// even if the operator did not forward the termination.
+func (o Observable) Lift(op func(downstream observer.Observer) observer.Observer) Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
+		terminated := false
+		downstream := observer.Observer{
+			NextHandler: func(item interface{}) {
+				if !terminated {
+					out <- item
+				}
+			},
+			ErrHandler: func(err error) {
+				if !terminated {
+					out <- err
+					terminated = true
+				}
+			},
+			DoneHandler: func() {
+				terminated = true
+			},
+		}
+		upstream := op(downstream)
+
 		for item := range o {
-			out <- apply(item)
+			if err, isErr := item.(error); isErr {
+				upstream.OnError(err)
+				terminated = true
+			} else {
+				upstream.OnNext(item)
+			}
+			if terminated {
+				break
+			}
+		}
+		if !terminated {
+			upstream.OnDone()
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "Lift", nil, o)
 }
 
-// Take takes first n items in the original Obserable and returns
-// a new Observable with the taken items.
-func (o Observable) Take(nth uint) Observable {
+// Map maps a MappableFunc predicate to each item in Observable and
+// returns a new Observable with applied items.
+func (o Observable) Map(apply fx.MappableFunc, opts ...Option) Observable {
+	return describe(fuse(o, opts, mapStage(apply)), "Map", nil, o)
+}
+
+// Tap calls onNext for each item, onError for an error and onDone on
+// completion without error, then passes the items through unchanged. Any of
+// the handlers may be nil. It is the place to hang logging and metrics on a
+// pipeline.
+func (o Observable) Tap(onNext handlers.NextFunc, onError handlers.ErrFunc, onDone handlers.DoneFunc) Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
-		takeCount := 0
+		failed := false
 		for item := range o {
-			if (takeCount < int(nth)) {
-				takeCount += 1
-				out <- item
-				continue
+			if err, isErr := item.(error); isErr {
+				failed = true
+				if onError != nil {
+					onError(err)
+				}
+			} else if onNext != nil {
+				onNext(item)
 			}
-			break
+			out <- item
+		}
+		if !failed && onDone != nil {
+			onDone()
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "Tap", nil, o)
+}
+
+// Take takes first n items in the original Obserable and returns
+// a new Observable with the taken items.
+func (o Observable) Take(nth uint) Observable {
+	return describe(fuse(o, nil, takeStage(nth)), "Take", map[string]interface{}{"n": nth}, o)
 }
 
 // TakeLast takes last n items in the original Observable and returns
 // a new Observable with the taken items.
 func (o Observable) TakeLast(nth uint) Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
 		buf := make([]interface{}, nth)
 		for item := range o {
@@ -134,57 +286,316 @@ func (o Observable) TakeLast(nth uint) Observable {
 		for _, takenItem := range buf {
 			out <- takenItem
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "TakeLast", map[string]interface{}{"n": nth}, o)
 }
 
-// Filter filters items in the original Observable and returns
-// a new Observable with the filtered items.
-func (o Observable) Filter(apply fx.FilterableFunc) Observable {
+// TakeWhile emits items from the original Observable for as long as they
+// satisfy a FilterableFunc predicate, and completes at the first item which
+// doesn't, unsubscribing from the original Observable.
+func (o Observable) TakeWhile(apply fx.FilterableFunc) Observable {
+	return describe(fuse(o, nil, takeWhileStage(apply)), "TakeWhile", nil, o)
+}
+
+// TakeUntil emits items from the original Observable until the other
+// Observable emits an item, then completes and unsubscribes from both. The
+// other Observable completing without emitting has no effect.
+func (o Observable) TakeUntil(other Observable) Observable {
+	out := make(chan interface{})
+	register(out, o, other)
+	go func() {
+		notifier := other
+	OuterLoop:
+		for {
+			select {
+			case item, ok := <-o:
+				if !ok {
+					break OuterLoop
+				}
+				out <- item
+			case _, ok := <-notifier:
+				if !ok {
+					notifier = nil
+					continue
+				}
+				break OuterLoop
+			}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "TakeUntil", nil, o, other)
+}
+
+// ElementAt emits only the item at the given index in the original Observable,
+// then completes and unsubscribes from it. If the original Observable
+// completes before reaching the index, the optional default item is emitted
+// instead, or a NoSuchElementError if none is given.
+func (o Observable) ElementAt(index uint, def ...interface{}) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		i := uint(0)
+		for item := range o {
+			if _, isErr := item.(error); isErr || i == index {
+				out <- item
+				closeStage(out)
+				return
+			}
+			i++
+		}
+		if len(def) > 0 {
+			out <- def[0]
+		} else {
+			out <- errors.New(errors.NoSuchElementError)
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "ElementAt", map[string]interface{}{"index": index}, o)
+}
+
+// IgnoreElements suppresses every item in the original Observable and only
+// passes on its termination, either an error or completion.
+func (o Observable) IgnoreElements() Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				break
+			}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "IgnoreElements", nil, o)
+}
+
+// DefaultIfEmpty emits the items in the original Observable, or the given
+// item if the original Observable completes without emitting any.
+func (o Observable) DefaultIfEmpty(def interface{}) Observable {
+	return describe(o.switchIfEmpty(func() Observable {
+		return Just(def)
+	}), "DefaultIfEmpty", nil, o)
+}
+
+// SwitchIfEmpty emits the items in the original Observable, or the items in
+// the fallback Observable if the original one completes without emitting any.
+func (o Observable) SwitchIfEmpty(fallback Observable) Observable {
+	return describe(o.switchIfEmpty(func() Observable {
+		return fallback
+	}), "SwitchIfEmpty", nil, o, fallback)
+}
+
+// switchIfEmpty emits the items in the original Observable, or the items of
+// the Observable built by fallback if there are none.
+func (o Observable) switchIfEmpty(fallback func() Observable) Observable {
 	out := make(chan interface{})
+	s := register(out, o)
+	go func() {
+		empty := true
+		for item := range o {
+			empty = false
+			out <- item
+		}
+		if empty && !s.isCancelled() {
+			for item := range fallback() {
+				out <- item
+			}
+		}
+		closeStage(out)
+	}()
+	return Observable(out)
+}
+
+// All emits true if every item in the original Observable satisfies a
+// FilterableFunc predicate, and false as soon as one doesn't, unsubscribing
+// from the original Observable.
+func (o Observable) All(apply fx.FilterableFunc) Observable {
+	return describe(o.decide(func(item interface{}) (bool, bool) {
+		return false, !apply(item)
+	}, true), "All", nil, o)
+}
+
+// Any emits true as soon as an item in the original Observable satisfies a
+// FilterableFunc predicate, unsubscribing from the original Observable, and
+// false if none does.
+func (o Observable) Any(apply fx.FilterableFunc) Observable {
+	return describe(o.decide(func(item interface{}) (bool, bool) {
+		return true, apply(item)
+	}, false), "Any", nil, o)
+}
+
+// Contains emits true as soon as the original Observable emits the given item,
+// unsubscribing from it, and false if it never does. Items are compared with reflect.DeepEqual
+// unless an optional EqualityFunc is given.
+func (o Observable) Contains(target interface{}, eq ...fx.EqualityFunc) Observable {
+	equal := reflect.DeepEqual
+	if len(eq) > 0 {
+		equal = eq[0]
+	}
+	return describe(o.Any(func(item interface{}) bool {
+		return equal(target, item)
+	}), "Contains", nil, o)
+}
+
+// IsEmpty emits true if the original Observable completes without emitting
+// any item, and false as soon as it emits one, unsubscribing from it.
+func (o Observable) IsEmpty() Observable {
+	return describe(o.decide(func(interface{}) (bool, bool) {
+		return false, true
+	}, true), "IsEmpty", nil, o)
+}
+
+// decide emits a single bool for the original Observable. It emits the result
+// of decision as soon as decision reports that it is final, or otherwise the
+// given fallback once the original Observable completes.
+func (o Observable) decide(decision func(interface{}) (result, final bool), fallback bool) Observable {
+	out := make(chan interface{}, 1)
+	register(out, o)
 	go func() {
 		for item := range o {
-			if apply(item) {
+			if _, isErr := item.(error); isErr {
 				out <- item
+				closeStage(out)
+				return
+			}
+			if result, final := decision(item); final {
+				out <- result
+				closeStage(out)
+				return
 			}
 		}
-		close(out)
+		out <- fallback
+		closeStage(out)
 	}()
 	return Observable(out)
 }
 
+// Filter filters items in the original Observable and returns
+// a new Observable with the filtered items.
+func (o Observable) Filter(apply fx.FilterableFunc, opts ...Option) Observable {
+	return describe(fuse(o, opts, filterStage(apply)), "Filter", nil, o)
+}
+
+// Partition splits the original Observable in two: the first new Observable
+// emits the items satisfying a FilterableFunc predicate and the second one the
+// items which don't. Both are fed by a single read of the original Observable
+// and receive its errors. Items wait in a queue until their half is consumed,
+// so neither half blocks the other. The items of a half which is unsubscribed
+// from are discarded, and the original Observable is unsubscribed from once
+// both halves are.
+func (o Observable) Partition(apply fx.FilterableFunc) (Observable, Observable) {
+	matches := make(chan interface{})
+	rest := make(chan interface{})
+	matchStage, restStage := register(matches), register(rest)
+	halves := int32(2)
+	drop := func() {
+		if atomic.AddInt32(&halves, -1) == 0 {
+			cancel(o)
+		}
+	}
+	matchStage.onCancel, restStage.onCancel = drop, drop
+
+	go func() {
+		var matchQueue, restQueue []interface{}
+		matchesDone, restDone := false, false
+		matchesCancelled, restCancelled := matchStage.cancelled(), restStage.cancelled()
+		in := o
+		for in != nil || len(matchQueue) > 0 || len(restQueue) > 0 {
+			var toMatches, toRest chan<- interface{}
+			var nextMatch, nextRest interface{}
+			if len(matchQueue) > 0 {
+				toMatches, nextMatch = matches, matchQueue[0]
+			}
+			if len(restQueue) > 0 {
+				toRest, nextRest = rest, restQueue[0]
+			}
+
+			select {
+			case item, ok := <-in:
+				if !ok {
+					in = nil
+				} else if _, isErr := item.(error); isErr {
+					matchQueue = append(matchQueue, item)
+					restQueue = append(restQueue, item)
+					in = nil
+				} else if apply(item) {
+					matchQueue = append(matchQueue, item)
+				} else {
+					restQueue = append(restQueue, item)
+				}
+			case toMatches <- nextMatch:
+				matchQueue = matchQueue[1:]
+			case toRest <- nextRest:
+				restQueue = restQueue[1:]
+			case <-matchesCancelled:
+				matchesCancelled = nil
+			case <-restCancelled:
+				restCancelled = nil
+			}
+			if matchStage.isCancelled() {
+				matchQueue = nil
+			}
+			if restStage.isCancelled() {
+				restQueue = nil
+			}
+
+			// Complete each half as soon as it has nothing left to emit.
+			if in == nil && len(matchQueue) == 0 && !matchesDone {
+				closeStage(matches)
+				matchesDone = true
+			}
+			if in == nil && len(restQueue) == 0 && !restDone {
+				closeStage(rest)
+				restDone = true
+			}
+		}
+		if !matchesDone {
+			closeStage(matches)
+		}
+		if !restDone {
+			closeStage(rest)
+		}
+	}()
+	return describe(Observable(matches), "Partition", map[string]interface{}{"half": "matches"}, o),
+		describe(Observable(rest), "Partition", map[string]interface{}{"half": "rest"}, o)
+}
+
 // First returns new Observable which emit only first item.
 func (o Observable) First() Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
 		for item := range o {
 			out <- item
 			break
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "First", nil, o)
 }
 
 // Last returns a new Observable which emit only last item.
 func (o Observable) Last() Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
 		var last interface{}
 		for item := range o {
 			last = item
 		}
 		out <- last
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "Last", nil, o)
 }
 
 // Distinct suppresses duplicate items in the original Observable and returns
 // a new Observable.
 func (o Observable) Distinct(apply fx.KeySelectorFunc) Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
 		keysets := make(map[interface{}]struct{})
 		for item := range o {
@@ -195,51 +606,122 @@ func (o Observable) Distinct(apply fx.KeySelectorFunc) Observable {
 			}
 			keysets[key] = struct{}{}
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "Distinct", nil, o)
 }
 
-// DistinctUntilChanged suppresses consecutive duplicate items in the original
-// Observable and returns a new Observable.
-func (o Observable) DistinctUntilChanged(apply fx.KeySelectorFunc) Observable {
+// DedupByKey suppresses items in the original Observable whose key, given by a
+// KeySelectorFunc, was already seen within the last ttl: each duplicate
+// extends the suppression of its key by ttl. At most maxKeys keys are
+// remembered, evicting the least recently seen key first; a maxKeys of zero
+// remembers every key until its ttl expires.
+func (o Observable) DedupByKey(apply fx.KeySelectorFunc, ttl time.Duration, maxKeys uint) Observable {
+	type entry struct {
+		key    interface{}
+		expiry time.Time
+	}
+
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
-		var current interface{}
+		recent := list.New()
+		index := make(map[interface{}]*list.Element)
+
 		for item := range o {
-			key := apply(item)
-			if current != key {
+			if _, isErr := item.(error); isErr {
 				out <- item
-				current = key
+				continue
 			}
+
+			now := time.Now()
+			key := apply(item)
+
+			// Forget expired keys from the least recently seen end.
+			for e := recent.Back(); e != nil && !e.Value.(*entry).expiry.After(now); e = recent.Back() {
+				delete(index, e.Value.(*entry).key)
+				recent.Remove(e)
+			}
+
+			if e, seen := index[key]; seen {
+				if e.Value.(*entry).expiry.After(now) {
+					// Keep the list ordered by expiry.
+					e.Value.(*entry).expiry = now.Add(ttl)
+					recent.MoveToFront(e)
+					continue
+				}
+				delete(index, key)
+				recent.Remove(e)
+			}
+
+			index[key] = recent.PushFront(&entry{key: key, expiry: now.Add(ttl)})
+			if maxKeys > 0 && uint(recent.Len()) > maxKeys {
+				e := recent.Back()
+				delete(index, e.Value.(*entry).key)
+				recent.Remove(e)
+			}
+			out <- item
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "DedupByKey", map[string]interface{}{"ttl": ttl, "maxKeys": maxKeys}, o)
+}
+
+// DistinctUntilChanged suppresses consecutive duplicate items in the original
+// Observable and returns a new Observable.
+func (o Observable) DistinctUntilChanged(apply fx.KeySelectorFunc) Observable {
+	return describe(fuse(o, nil, distinctUntilChangedStage(apply)), "DistinctUntilChanged", nil, o)
 }
 
 // Skip suppresses the first n items in the original Observable and 
 // returns a new Observable with the rest items.
 func (o Observable) Skip(nth uint) Observable {
+	return describe(fuse(o, nil, skipStage(nth)), "Skip", map[string]interface{}{"n": nth}, o)
+}
+
+// SkipWhile suppresses items in the original Observable for as long as they
+// satisfy a FilterableFunc predicate, and emits every item from the first one
+// which doesn't.
+func (o Observable) SkipWhile(apply fx.FilterableFunc) Observable {
+	return describe(fuse(o, nil, skipWhileStage(apply)), "SkipWhile", nil, o)
+}
+
+// SkipUntil suppresses items in the original Observable until the other
+// Observable emits an item, and emits every item after that. If the other
+// Observable completes without emitting, every item is suppressed.
+func (o Observable) SkipUntil(other Observable) Observable {
 	out := make(chan interface{})
+	register(out, o, other)
 	go func() {
-		skipCount := 0
-		for item := range o {
-			if (skipCount < int(nth)) {
-				skipCount += 1
-				continue
+		triggered := false
+		notifier := other
+	OuterLoop:
+		for {
+			select {
+			case item, ok := <-o:
+				if !ok {
+					break OuterLoop
+				}
+				if triggered {
+					out <- item
+				}
+			case _, ok := <-notifier:
+				if ok {
+					triggered = true
+				}
+				notifier = nil
 			}
-			out <- item
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "SkipUntil", nil, o, other)
 }
 
 // SkipLast suppresses the last n items in the original Observable and
 // returns a new Observable with the rest items.
 func (o Observable) SkipLast(nth uint) Observable {
 	out := make(chan interface{})
+	register(out, o)
 	go func() {
 		buf := make(chan interface{}, nth)
 		for item := range o {
@@ -251,16 +733,46 @@ func (o Observable) SkipLast(nth uint) Observable {
 			}
 		}
 		close(buf)
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "SkipLast", map[string]interface{}{"n": nth}, o)
+}
+
+
+// Pair holds two consecutive items of an Observable.
+type Pair struct {
+	Previous interface{}
+	Current  interface{}
 }
 
+// Pairwise emits a Pair of each item of the original Observable with the
+// one before it, starting from the second item.
+func (o Observable) Pairwise() Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		var previous interface{}
+		started := false
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+			if started {
+				out <- Pair{Previous: previous, Current: item}
+			}
+			previous, started = item, true
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Pairwise", nil, o)
+}
 
 // Scan applies ScannableFunc predicate to each item in the original
 // Observable sequentially and emits each successive value on a new Observable.
-func (o Observable) Scan(apply fx.ScannableFunc) Observable {
-	out := make(chan interface{})
+func (o Observable) Scan(apply fx.ScannableFunc, opts ...Option) Observable {
+	out := newChannel(opts)
+	register(out, o)
 
 	go func() {
 		var current interface{}
@@ -268,25 +780,166 @@ func (o Observable) Scan(apply fx.ScannableFunc) Observable {
 			out <- apply(current, item)
 			current = apply(current, item)
 		}
-		close(out)
+		closeStage(out)
 	}()
-	return Observable(out)
+	return describe(Observable(out), "Scan", nil, o)
+}
+
+// Collect folds the items of the original Observable into a mutable container,
+// such as a *strings.Builder or a pointer to an aggregate struct, and emits the
+// container once the original Observable completes. The container is created by
+// supplier and each item is added to it with accumulate.
+func (o Observable) Collect(supplier fx.EmittableFunc, accumulate fx.AccumulatorFunc) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		container := supplier()
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				closeStage(out)
+				return
+			}
+			accumulate(container, item)
+		}
+		out <- container
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Collect", nil, o)
+}
+
+// pipelineWindow bounds how many items Pipeline may have in flight at once.
+const pipelineWindow = 64
+
+// Pipeline applies every stage to each item in the original Observable as a
+// single task on the given Scheduler, instead of spawning a goroutine and a
+// channel per stage as chained Map calls do. Items are processed concurrently
+// but emitted on the new Observable in their original order.
+func (o Observable) Pipeline(sched scheduler.Scheduler, stages ...fx.MappableFunc) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	pending := newSPSCRing(pipelineWindow)
+
+	go func() {
+		for item := range o {
+			result := make(chan interface{}, 1)
+			pending.put(result)
+			sched.Schedule(runStages(item, stages, result))
+		}
+		pending.close()
+	}()
+
+	go func() {
+		for result, ok := pending.get(); ok; result, ok = pending.get() {
+			out <- <-result.(chan interface{})
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Pipeline", map[string]interface{}{"stages": len(stages)}, o)
+}
+
+// runStages returns a task which applies stages to item in sequence and
+// sends the outcome on result.
+func runStages(item interface{}, stages []fx.MappableFunc, result chan<- interface{}) func() {
+	return func() {
+		for _, apply := range stages {
+			item = apply(item)
+		}
+		result <- item
+	}
+}
+
+// ObserveOn hands the items of the original Observable to its subscriber
+// from a goroutine of its own, through a lock-free queue of capacity items,
+// so that a slow subscriber doesn't stall the stages upstream item by item.
+// It is the explicit asynchronous boundary of a pipeline whose synchronous
+// stages are fused.
+func (o Observable) ObserveOn(capacity uint) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	queue := newSPSCRing(capacity)
+
+	go func() {
+		for item := range o {
+			queue.put(item)
+		}
+		queue.close()
+	}()
+
+	go func() {
+		for item, ok := queue.get(); ok; item, ok = queue.get() {
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "ObserveOn", map[string]interface{}{"capacity": capacity}, o)
+}
+
+// RateLimit limits the original Observable to n items per given duration using
+// a token bucket, delaying items which arrive faster than that. An optional
+// burst sets how many items may be emitted back to back; it defaults to one,
+// which spaces items evenly. Errors are passed on without delay.
+func (o Observable) RateLimit(n uint, per time.Duration, burst ...uint) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		capacity := 1.0
+		if len(burst) > 0 && burst[0] > 0 {
+			capacity = float64(burst[0])
+		}
+		rate := float64(n) / float64(per)
+		tokens := capacity
+		last := time.Now()
+
+		for item := range o {
+			if _, isErr := item.(error); !isErr && n > 0 {
+				now := time.Now()
+				tokens += float64(now.Sub(last)) * rate
+				if tokens > capacity {
+					tokens = capacity
+				}
+				last = now
+
+				if tokens < 1 {
+					wait := time.Duration((1 - tokens) / rate)
+					<-time.After(wait)
+					tokens = 1
+					last = last.Add(wait)
+				}
+				tokens--
+			}
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "RateLimit", map[string]interface{}{"n": n, "per": per}, o)
 }
 
 // From creates a new Observable from an Iterator.
-func From(it rx.Iterator) Observable {
-	source := make(chan interface{})
+func From(it rx.Iterator, opts ...Option) Observable {
+	// An Iterable is already a channel, so it needs no goroutine of its own.
+	if it, ok := it.(iterable.Iterable); ok && len(opts) == 0 {
+		return created("From", Observable(it))
+	}
+	source := newChannel(opts)
+	s := register(source)
 	go func() {
-		for {
+		for !s.isCancelled() {
 			val, err := it.Next()
 			if err != nil {
 				break
 			}
 			source <- val
 		}
-		close(source)
+		closeStage(source)
 	}()
-	return Observable(source)
+	return created("From", Observable(source))
+}
+
+// FromChannel creates an Observable emitting the items received on a channel,
+// which completes when the channel is closed.
+func FromChannel(ch <-chan interface{}) Observable {
+	return created("FromChannel", Observable(ch))
 }
 
 // Empty creates an Observable with no item and terminate immediately.
@@ -295,13 +948,31 @@ func Empty() Observable {
 	go func() {
 		close(source)
 	}()
-	return Observable(source)
+	return created("Empty", Observable(source))
+}
+
+// Never creates an Observable which emits no item and never terminates.
+func Never() Observable {
+	return created("Never", Observable(make(chan interface{})))
+}
+
+// Throw creates an Observable which emits an error and terminates immediately.
+func Throw(err error) Observable {
+	source := make(chan interface{}, 1)
+	source <- err
+	close(source)
+	return created("Throw", Observable(source))
 }
 
 // Interval creates an Observable emitting incremental integers infinitely between
-// each given time interval.
-func Interval(term chan struct{}, interval time.Duration) Observable {
-	source := make(chan interface{})
+// each given time interval. An optional BackpressureStrategy decides what happens
+// to ticks a slow subscriber can't keep up with; by default Interval blocks.
+func Interval(term chan struct{}, interval time.Duration, strategy ...BackpressureStrategy) Observable {
+	e := newEmitter(Block)
+	if len(strategy) > 0 {
+		e = newEmitter(strategy[0])
+	}
+	s := register(e.out)
 	go func(term chan struct{}) {
 		i := 0
 	OuterLoop:
@@ -309,80 +980,144 @@ func Interval(term chan struct{}, interval time.Duration) Observable {
 			select {
 			case <-term:
 				break OuterLoop
+			case <-s.cancelled():
+				break OuterLoop
 			case <-time.After(interval):
-				source <- i
+				if !e.emit(i) {
+					break OuterLoop
+				}
 			}
 			i++
 		}
-		close(source)
+		closeStage(e.out)
 	}(term)
-	return Observable(source)
+	return created("Interval", Observable(e.out))
 }
 
 // Repeat creates an Observable emitting a given item repeatedly
 func Repeat(item interface{}, ntimes ...int) Observable {
 	source := make(chan interface{})
+	s := register(source)
 	
 	// this is the infinity case no ntime parameter is given
 	if len(ntimes) == 0 {
 		go func() {
-			for {
+			for !s.isCancelled() {
 				source <- item
 			}
-			close(source)
+			closeStage(source)
 		}()
-		return Observable(source)
+		return created("Repeat", Observable(source))
 	}
 
 	// this repeat the item ntime
 	if len(ntimes) > 0 {
 		count := ntimes[0]
 		if count <= 0 {
+			closeStage(source)
 			return Empty()
 		}
 		go func() {
-			for i := 0; i < count; i++ {
+			for i := 0; i < count && !s.isCancelled(); i++ {
 				source <- item
 			}
-			close(source)
+			closeStage(source)
 		}()
-		return Observable(source)
+		return created("Repeat", Observable(source))
 	}
 
 	return Empty()
 }
 
-// Range creates an Observable that emits a particular range of sequential integers.
-func Range(start, end int) Observable {
+// RepeatFrom subscribes to the Observable built by factory and, every time it
+// completes, builds and subscribes to a fresh one, so cold sources such as
+// Start are run again. It repeats infinitely, or ntimes in total when given,
+// waiting delay between the end of one run and the start of the next.
+// Repetition stops at the first error.
+func RepeatFrom(factory func() Observable, delay time.Duration, ntimes ...int) Observable {
 	source := make(chan interface{})
+	s := register(source)
+	go func() {
+	OuterLoop:
+		for i := 0; len(ntimes) == 0 || i < ntimes[0]; i++ {
+			if i > 0 && delay > 0 {
+				select {
+				case <-time.After(delay):
+				case <-s.cancelled():
+					break OuterLoop
+				}
+			}
+			if s.isCancelled() {
+				break
+			}
+			current := factory()
+			s.follow(current)
+			for item := range current {
+				source <- item
+				if _, isErr := item.(error); isErr {
+					break OuterLoop
+				}
+			}
+		}
+		closeStage(source)
+	}()
+	return created("RepeatFrom", Observable(source))
+}
+
+// Range creates an Observable that emits a particular range of sequential integers,
+// from start up to but excluding end. The integers are generated lazily as the
+// subscriber consumes them, and an end at or before start emits nothing.
+func Range(start, end int, opts ...Option) Observable {
+	source := newChannel(opts)
+	s := register(source)
 	go func() {
 		i := start
-		for i < end {
+		for i < end && !s.isCancelled() {
 			source <- i
 			i++
 		}
-		close(source)
+		closeStage(source)
 	}()
-	return Observable(source)
+	return created("Range", Observable(source))
 }
 
-// Just creates an Observable with the provided item(s).
-func Just(item interface{}, items ...interface{}) Observable {
+// Generate creates an Observable from a loop over a state value. Starting from
+// initial, it emits result(state) and advances the state with iterate for as long
+// as condition holds. The state is only ever touched by the generating goroutine.
+func Generate(initial interface{}, condition fx.FilterableFunc, iterate, result fx.MappableFunc) Observable {
 	source := make(chan interface{})
-	if len(items) > 0 {
-		items = append([]interface{}{item}, items...)
-	} else {
-		items = []interface{}{item}
-	}
-
+	s := register(source)
 	go func() {
-		for _, item := range items {
-			source <- item
+		for state := initial; condition(state) && !s.isCancelled(); state = iterate(state) {
+			source <- result(state)
 		}
-		close(source)
+		closeStage(source)
 	}()
+	return created("Generate", Observable(source))
+}
+
+// one returns a closed channel holding only item.
+func one(item interface{}) <-chan interface{} {
+	out := make(chan interface{}, 1)
+	out <- item
+	close(out)
+	return out
+}
 
-	return Observable(source)
+// Just creates an Observable with the provided item(s). The items are known
+// up front, so they are buffered straight away and no goroutine is needed;
+// a single item is the cheapest Observable there is.
+func Just(item interface{}, items ...interface{}) Observable {
+	if len(items) == 0 {
+		return created("Just", Observable(one(item)))
+	}
+	source := make(chan interface{}, len(items)+1)
+	source <- item
+	for _, item := range items {
+		source <- item
+	}
+	close(source)
+	return created("Just", Observable(source))
 }
 
 // Start creates an Observable from one or more directive-like EmittableFunc
@@ -411,5 +1146,5 @@ func Start(f fx.EmittableFunc, fs ...fx.EmittableFunc) Observable {
 		close(source)
 	}()
 
-	return Observable(source)
+	return created("Start", Observable(source))
 }
@@ -1,8 +1,10 @@
 package observable
 
 import (
+	"bytes"
 	"errors"
 	"net/http"
+	"runtime"
 	"testing"
 	"time"
 
@@ -10,6 +12,7 @@ import (
 	"github.com/reactivex/rxgo/handlers"
 	"github.com/reactivex/rxgo/iterable"
 	"github.com/reactivex/rxgo/observer"
+	"github.com/reactivex/rxgo/scheduler"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -67,6 +70,40 @@ func TestEmptyOperator(t *testing.T) {
 	assert.Equal(t, "done", text)
 }
 
+func TestNeverOperator(t *testing.T) {
+	myStream := Never()
+	done := false
+
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+	sub := myStream.Subscribe(onDone)
+
+	select {
+	case <-sub:
+		t.Fail()
+	case <-time.After(10 * time.Millisecond):
+	}
+	assert.False(t, done)
+}
+
+func TestThrowOperator(t *testing.T) {
+	myStream := Throw(errors.New("bang"))
+	text := ""
+
+	onError := handlers.ErrFunc(func(err error) {
+		text += err.Error()
+	})
+	onDone := handlers.DoneFunc(func() {
+		text += "done"
+	})
+	sub := myStream.Subscribe(observer.New(onError, onDone))
+	s := <-sub
+
+	assert.Equal(t, "bang", text)
+	assert.EqualError(t, s.Err(), "bang")
+}
+
 func TestIntervalOperator(t *testing.T) {
 	fin := make(chan struct{})
 	myStream := Interval(fin, 10*time.Millisecond)
@@ -108,6 +145,46 @@ func TestRangeOperator(t *testing.T) {
 	assert.Exactly(t, []int{2, 3, 4, 5, 1000}, nums)
 }
 
+func TestRangeOperatorWithEmptyRange(t *testing.T) {
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := Range(5, 5).Subscribe(onNext)
+	<-sub
+	sub = Range(5, 2).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{}, nums)
+}
+
+func TestGenerateOperator(t *testing.T) {
+	condition := func(state interface{}) bool {
+		return state.(int) < 100
+	}
+	iterate := func(state interface{}) interface{} {
+		return state.(int) * 2
+	}
+	result := func(state interface{}) interface{} {
+		return state.(int) * 10
+	}
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := Generate(1, condition, iterate, result).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{10, 20, 40, 80, 160, 320, 640}, nums)
+}
+
 func TestJustOperator(t *testing.T) {
 	myStream := Just(1, 2.01, "foo", map[string]string{"bar": "baz"}, 'a')
 	//numItems := 5
@@ -155,6 +232,33 @@ func TestFromOperator(t *testing.T) {
 	}
 }
 
+func TestFromChannelOperator(t *testing.T) {
+	ch := make(chan interface{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+	done := false
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+
+	sub := FromChannel(ch).Subscribe(observer.New(onNext, onDone))
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2}, nums)
+	assert.True(t, done)
+}
+
 func fakeGet(url string, delay time.Duration, result interface{}) (interface{}, error) {
 	<-time.After(delay)
 	if err, isErr := result.(error); isErr {
@@ -394,6 +498,171 @@ func TestObservableMap(t *testing.T) {
 	assert.Exactly(t, []int{10, 20, 30}, nums)
 }
 
+func TestObservableTap(t *testing.T) {
+	var seen []interface{}
+	done := false
+	items, err := Just(1, 2, 3).Tap(func(item interface{}) {
+		seen = append(seen, item)
+	}, nil, func() {
+		done = true
+	}).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 2, 3}, items)
+	assert.Equal(t, items, seen)
+	assert.True(t, done)
+
+	var tapped error
+	done = false
+	_, err = Just(1, errors.New("bang")).Tap(nil, func(err error) {
+		tapped = err
+	}, func() {
+		done = true
+	}).ToSlice()
+
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, err, tapped)
+	assert.False(t, done)
+}
+
+func TestObservableFluentChain(t *testing.T) {
+	it, err := iterable.New([]interface{}{1, 2, 3, 4, 5})
+	assert.Nil(t, err)
+
+	nums := []int{}
+	sub := From(it).
+		Map(func(item interface{}) interface{} {
+			return item.(int) * 10
+		}).
+		Filter(func(item interface{}) bool {
+			return item.(int) > 20
+		}).
+		Take(2).
+		Subscribe(handlers.NextFunc(func(item interface{}) {
+			nums = append(nums, item.(int))
+		}))
+	<-sub
+
+	assert.Exactly(t, []int{30, 40}, nums)
+}
+
+func TestObservablePipe(t *testing.T) {
+	double := func(o Observable) Observable {
+		return o.Map(func(item interface{}) interface{} {
+			return item.(int) * 2
+		})
+	}
+	firstTwo := func(o Observable) Observable {
+		return o.Take(2)
+	}
+
+	items, err := Just(1, 2, 3).Pipe(double, firstTwo).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 4}, items)
+
+	quadrupleFirstTwo := Compose(double, double, firstTwo)
+	items, err = Just(1, 2, 3).Pipe(quadrupleFirstTwo).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{4, 8}, items)
+
+	items, err = Just(1).Pipe().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestObservableLift(t *testing.T) {
+	// everyOther forwards every second item.
+	everyOther := func(downstream observer.Observer) observer.Observer {
+		odd := false
+		return observer.Observer{
+			NextHandler: func(item interface{}) {
+				if odd {
+					downstream.OnNext(item)
+				}
+				odd = !odd
+			},
+			ErrHandler:  downstream.OnError,
+			DoneHandler: downstream.OnDone,
+		}
+	}
+	items, err := Just(1, 2, 3, 4, 5).Lift(everyOther).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 4}, items)
+
+	// untilNegative completes on the first negative item.
+	seen := 0
+	untilNegative := func(downstream observer.Observer) observer.Observer {
+		return observer.Observer{
+			NextHandler: func(item interface{}) {
+				seen++
+				if item.(int) < 0 {
+					downstream.OnDone()
+					return
+				}
+				downstream.OnNext(item)
+			},
+		}
+	}
+	items, err = Just(1, -1, 2).Lift(untilNegative).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+	assert.Equal(t, 2, seen)
+
+	_, err = Just(1, errors.New("bang")).Lift(everyOther).ToSlice()
+	assert.EqualError(t, err, "bang")
+}
+
+func TestObservableLiftCancelsSource(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	takeThree := func(downstream observer.Observer) observer.Observer {
+		taken := 0
+		return observer.Observer{
+			NextHandler: func(item interface{}) {
+				downstream.OnNext(item)
+				if taken++; taken == 3 {
+					downstream.OnDone()
+				}
+			},
+		}
+	}
+	items, err := Interval(make(chan struct{}), time.Millisecond).Lift(takeThree).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{0, 1, 2}, items)
+	waitGoroutines(t, baseline)
+}
+
+func TestObservableSubscribeFunc(t *testing.T) {
+	var items []interface{}
+	sub := <-Just(1, 2).SubscribeFunc(func(item interface{}) {
+		items = append(items, item)
+	})
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []interface{}{1, 2}, items)
+}
+
+func TestObservableSubscribeAll(t *testing.T) {
+	var items []interface{}
+	var failure error
+	done := false
+	sub := <-Just(1, errors.New("bang")).SubscribeAll(func(item interface{}) {
+		items = append(items, item)
+	}, func(err error) {
+		failure = err
+	}, func() {
+		done = true
+	})
+	assert.EqualError(t, sub.Err(), "bang")
+	assert.Equal(t, []interface{}{1}, items)
+	assert.EqualError(t, failure, "bang")
+	assert.False(t, done)
+
+	sub = <-Just(1).SubscribeAll(nil, nil, func() {
+		done = true
+	})
+	assert.Nil(t, sub.Err())
+	assert.True(t, done)
+}
+
 func TestObservableTake(t *testing.T) {
 	items := []interface{}{1, 2, 3, 4, 5}
 	it, err := iterable.New(items)
@@ -475,6 +744,203 @@ func TestObservableTakeLastWithEmpty(t *testing.T) {
 	assert.Exactly(t, []int{}, nums)
 }*/
 
+func TestObservableTakeWhile(t *testing.T) {
+	lessThan3 := func(item interface{}) bool {
+		return item.(int) < 3
+	}
+
+	items, err := Just(0, 1, 2, 3, 0).TakeWhile(lessThan3).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{0, 1, 2}, items)
+}
+
+func TestObservableTakeUntil(t *testing.T) {
+	source := make(chan interface{})
+	signal := make(chan interface{})
+	stream := Observable(source).TakeUntil(Observable(signal))
+
+	go func() {
+		source <- 1
+		source <- 2
+		signal <- struct{}{}
+	}()
+
+	items, err := stream.ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{1, 2}, items)
+}
+
+func TestObservableTakeUntilWithEmptyOther(t *testing.T) {
+	items, err := Range(0, 3).TakeUntil(Empty()).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{0, 1, 2}, items)
+}
+
+func TestObservableTakeWhileStopsProducer(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	items, err := Interval(make(chan struct{}), time.Millisecond).TakeWhile(func(item interface{}) bool {
+		return item.(int) < 3
+	}).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{0, 1, 2}, items)
+	waitGoroutines(t, baseline)
+}
+
+func TestObservableTakeUntilStopsProducers(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	stop := Interval(make(chan struct{}), 20*time.Millisecond)
+	items, err := Interval(make(chan struct{}), time.Millisecond).TakeUntil(stop).ToSlice()
+	assert.Nil(t, err)
+	assert.NotEmpty(t, items)
+	waitGoroutines(t, baseline)
+}
+
+func TestObservableElementAt(t *testing.T) {
+	items, err := Range(10, 20).ElementAt(3).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{13}, items)
+
+	items, err = Range(10, 12).ElementAt(3, -1).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{-1}, items)
+
+	_, err = Range(10, 12).ElementAt(3).ToSlice()
+	assert.Error(t, err)
+}
+
+func TestObservableElementAtStopsProducer(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	items, err := Interval(make(chan struct{}), time.Millisecond).ElementAt(2).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{2}, items)
+	waitGoroutines(t, baseline)
+}
+
+func TestObservableIgnoreElements(t *testing.T) {
+	items, err := Range(0, 5).IgnoreElements().ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{}, items)
+
+	items, err = Just(1, errors.New("bang")).IgnoreElements().ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Exactly(t, []interface{}{}, items)
+}
+
+func TestObservableDefaultIfEmpty(t *testing.T) {
+	items, err := Empty().DefaultIfEmpty(7).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{7}, items)
+
+	items, err = Just(1, 2).DefaultIfEmpty(7).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{1, 2}, items)
+}
+
+func TestObservableSwitchIfEmpty(t *testing.T) {
+	items, err := Empty().SwitchIfEmpty(Range(0, 3)).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{0, 1, 2}, items)
+
+	items, err = Just(7).SwitchIfEmpty(Range(0, 3)).ToSlice()
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{7}, items)
+}
+
+func TestObservableAll(t *testing.T) {
+	positive := func(item interface{}) bool {
+		return item.(int) > 0
+	}
+
+	result, err := Just(1, 2, 3).All(positive).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, true, result)
+
+	result, err = Just(1, -2, 3).All(positive).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, false, result)
+}
+
+func TestObservableAny(t *testing.T) {
+	negative := func(item interface{}) bool {
+		return item.(int) < 0
+	}
+
+	result, err := Just(1, -2, 3).Any(negative).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, true, result)
+
+	result, err = Empty().Any(negative).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, false, result)
+}
+
+func TestObservableAnyShortCircuits(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	negative := func(item interface{}) bool {
+		return item.(int) < 0
+	}
+
+	result, err := Interval(make(chan struct{}), time.Millisecond).
+		Map(func(item interface{}) interface{} {
+			return 5 - item.(int)
+		}).
+		Any(negative).
+		BlockingSingle()
+
+	assert.Nil(t, err)
+	assert.Equal(t, true, result)
+	waitGoroutines(t, baseline)
+}
+
+func TestObservablePredicatesStopProducer(t *testing.T) {
+	tests := map[string]func(Observable) Observable{
+		"All": func(o Observable) Observable {
+			return o.All(func(item interface{}) bool { return item.(int) < 2 })
+		},
+		"Contains": func(o Observable) Observable { return o.Contains(2) },
+		"IsEmpty":  func(o Observable) Observable { return o.IsEmpty() },
+	}
+	for name, predicate := range tests {
+		baseline := runtime.NumGoroutine()
+		_, err := predicate(Interval(make(chan struct{}), time.Millisecond)).BlockingSingle()
+		assert.Nil(t, err, name)
+		waitGoroutines(t, baseline)
+	}
+}
+
+func TestObservableContains(t *testing.T) {
+	result, err := Just("foo", "bar").Contains("bar").BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, true, result)
+
+	sameLength := func(a, b interface{}) bool {
+		return len(a.(string)) == len(b.(string))
+	}
+	result, err = Just("foo", "bar").Contains("hello", sameLength).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, false, result)
+
+	result, err = Just([]int{1}, map[string]int{"a": 1}).Contains([]int{1}).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, true, result)
+}
+
+func TestObservableIsEmpty(t *testing.T) {
+	result, err := Empty().IsEmpty().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, true, result)
+
+	result, err = Just(1).IsEmpty().BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, false, result)
+
+	_, err = Throw(errors.New("bang")).IsEmpty().BlockingSingle()
+	assert.EqualError(t, err, "bang")
+}
+
 func TestObservableFilter(t *testing.T) {
 	items := []interface{}{1, 2, 3, 120, []byte("baz"), 7, 10, 13}
 	it, err := iterable.New(items)
@@ -510,6 +976,56 @@ func TestObservableFilter(t *testing.T) {
 	assert.Exactly(t, []int{1, 2, 3, 7}, nums)
 }
 
+func TestObservablePartition(t *testing.T) {
+	even := func(item interface{}) bool {
+		return item.(int)%2 == 0
+	}
+
+	evens, odds := Range(0, 7).Partition(even)
+
+	// Draining one half entirely first must not block on the other.
+	oddItems, err := odds.ToSlice()
+	assert.Nil(t, err)
+	evenItems, err := evens.ToSlice()
+	assert.Nil(t, err)
+
+	assert.Exactly(t, []interface{}{1, 3, 5}, oddItems)
+	assert.Exactly(t, []interface{}{0, 2, 4, 6}, evenItems)
+}
+
+func TestObservablePartitionWithError(t *testing.T) {
+	even := func(item interface{}) bool {
+		return item.(int)%2 == 0
+	}
+
+	evens, odds := Just(0, 1, errors.New("bang"), 2).Partition(even)
+
+	evenItems, err := evens.ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Exactly(t, []interface{}{0}, evenItems)
+
+	oddItems, err := odds.ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Exactly(t, []interface{}{1}, oddItems)
+}
+
+func TestObservablePartitionUnsubscribe(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	even := func(item interface{}) bool {
+		return item.(int)%2 == 0
+	}
+	evens, odds := Interval(make(chan struct{}), time.Millisecond).Partition(even)
+
+	assert.Equal(t, 0, <-evens)
+	evens.Unsubscribe()
+
+	// The other half carries on until it is unsubscribed from too.
+	assert.Equal(t, 1, <-odds)
+	assert.Equal(t, 3, <-odds)
+	odds.Unsubscribe()
+	waitGoroutines(t, baseline)
+}
+
 func TestObservableFirst(t *testing.T) {
 	items := []interface{}{0, 1, 3}
 	it, err := iterable.New(items)
@@ -635,6 +1151,36 @@ func TestObservableSkipWithEmpty(t *testing.T) {
 	assert.Exactly(t, []int{}, nums)	
 }
 
+func TestObservableSkipWhile(t *testing.T) {
+	lessThan3 := func(item interface{}) bool {
+		return item.(int) < 3
+	}
+
+	items, err := Just(0, 1, 2, 3, 0).SkipWhile(lessThan3).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{3, 0}, items)
+}
+
+func TestObservableSkipUntil(t *testing.T) {
+	source := make(chan interface{})
+	signal := make(chan interface{})
+	stream := Observable(source).SkipUntil(Observable(signal))
+
+	go func() {
+		source <- 1
+		source <- 2
+		signal <- struct{}{}
+		source <- 3
+		close(source)
+	}()
+
+	items, err := stream.ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{3}, items)
+}
+
 func TestObservableSkipLast(t *testing.T) {
 	items := []interface{}{0, 1, 3, 5, 1, 8}
 	it, err := iterable.New(items)
@@ -706,6 +1252,63 @@ func TestObservableDistinct(t *testing.T) {
 	assert.Exactly(t, []int{1, 2, 3}, nums)
 }
 
+func TestObservableDedupByKey(t *testing.T) {
+	identity := func(item interface{}) interface{} {
+		return item
+	}
+
+	source := make(chan interface{})
+	stream := Observable(source).DedupByKey(identity, 20*time.Millisecond, 0)
+	go func() {
+		for _, item := range []interface{}{"a", "b", "a", "b"} {
+			source <- item
+		}
+		<-time.After(30 * time.Millisecond)
+		source <- "a"
+		close(source)
+	}()
+
+	items, err := stream.ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{"a", "b", "a"}, items)
+}
+
+func TestObservableDedupByKeyExtendsTTL(t *testing.T) {
+	identity := func(item interface{}) interface{} {
+		return item
+	}
+
+	source := make(chan interface{})
+	stream := Observable(source).DedupByKey(identity, 100*time.Millisecond, 0)
+	go func() {
+		source <- "a"
+		source <- "b"
+		<-time.After(60 * time.Millisecond)
+		source <- "a"
+		<-time.After(60 * time.Millisecond)
+		source <- "a"
+		source <- "b"
+		close(source)
+	}()
+
+	items, err := stream.ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{"a", "b", "b"}, items)
+}
+
+func TestObservableDedupByKeyEvictsLeastRecentKey(t *testing.T) {
+	identity := func(item interface{}) interface{} {
+		return item
+	}
+
+	items, err := Just("a", "b", "a", "c", "b", "a").DedupByKey(identity, time.Hour, 2).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []interface{}{"a", "b", "c", "b", "a"}, items)
+}
+
 func TestObservableDistinctUntilChanged(t *testing.T) {
 	items := []interface{}{1, 2, 2, 1, 3}
 	it, err := iterable.New(items)
@@ -734,6 +1337,16 @@ func TestObservableDistinctUntilChanged(t *testing.T) {
 	assert.Exactly(t, []int{1, 2, 1, 3}, nums)
 }
 
+func TestObservablePairwise(t *testing.T) {
+	items, err := Just(1, 3, 6).Pairwise().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{Pair{1, 3}, Pair{3, 6}}, items)
+
+	items, err = Just(1).Pairwise().ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
 func TestObservableScanWithIntegers(t *testing.T) {
 	items := []interface{}{0, 1, 3, 5, 1, 8}
 	it, err := iterable.New(items)
@@ -814,6 +1427,165 @@ func TestObservableScanWithString(t *testing.T) {
 	assert.Exactly(t, expected, words)
 }
 
+func TestObservableObserveOn(t *testing.T) {
+	items, err := Range(0, 1000).Map(func(item interface{}) interface{} {
+		return item.(int) + 1
+	}).ObserveOn(16).ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, items, 1000)
+	assert.Equal(t, 1000, items[999])
+
+	items, err = Just(1, errors.New("bang"), 2).ObserveOn(1).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestObservableObserveOnUnsubscribe(t *testing.T) {
+	baseline := runtime.NumGoroutine()
+	o := Interval(make(chan struct{}), time.Millisecond).ObserveOn(4)
+	assert.Equal(t, 0, <-o)
+	assert.Equal(t, 1, <-o)
+	o.Unsubscribe()
+	waitGoroutines(t, baseline)
+}
+
+func TestObservablePipeline(t *testing.T) {
+	ws := scheduler.NewWorkStealing(4)
+	defer ws.Close()
+
+	stages := []fx.MappableFunc{}
+	for i := 0; i < 12; i++ {
+		stages = append(stages, func(item interface{}) interface{} {
+			return item.(int) + 1
+		})
+	}
+
+	stream := Range(0, 100).Pipeline(ws, stages...)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := stream.Subscribe(onNext)
+	<-sub
+
+	expected := []int{}
+	for i := 0; i < 100; i++ {
+		expected = append(expected, i+12)
+	}
+	assert.Exactly(t, expected, nums)
+}
+
+func TestObservableRateLimit(t *testing.T) {
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	start := time.Now()
+	sub := Range(0, 5).RateLimit(1, 10*time.Millisecond).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2, 3, 4}, nums)
+	assert.True(t, time.Since(start) >= 40*time.Millisecond)
+}
+
+func TestObservableRateLimitWithBurst(t *testing.T) {
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	start := time.Now()
+	sub := Range(0, 5).RateLimit(1, time.Second, 5).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2, 3, 4}, nums)
+	assert.True(t, time.Since(start) < 500*time.Millisecond)
+}
+
+func TestRepeatFromOperator(t *testing.T) {
+	calls := 0
+	poll := func() Observable {
+		calls++
+		return Start(func() interface{} {
+			return calls
+		})
+	}
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	start := time.Now()
+	sub := RepeatFrom(poll, 5*time.Millisecond, 3).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{1, 2, 3}, nums)
+	assert.True(t, time.Since(start) >= 10*time.Millisecond)
+}
+
+func TestRepeatFromInfinityOperator(t *testing.T) {
+	factory := func() Observable {
+		return Just(1, 2)
+	}
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := RepeatFrom(factory, 0).Take(5).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{1, 2, 1, 2, 1}, nums)
+}
+
+func TestRepeatFromStopsOnError(t *testing.T) {
+	calls := 0
+	factory := func() Observable {
+		calls++
+		return Just(calls, errors.New("bang"))
+	}
+
+	sub := RepeatFrom(factory, 0, 3).Subscribe(handlers.ErrFunc(func(error) {}))
+	s := <-sub
+
+	assert.NotNil(t, s.Err())
+	assert.Equal(t, 1, calls)
+}
+
+func TestObservableCollect(t *testing.T) {
+	supplier := func() interface{} {
+		return new(bytes.Buffer)
+	}
+	accumulate := func(container, item interface{}) {
+		container.(*bytes.Buffer).WriteString(item.(string))
+	}
+
+	text := ""
+	onNext := handlers.NextFunc(func(item interface{}) {
+		text = item.(*bytes.Buffer).String()
+	})
+
+	sub := Just("foo", "bar", "baz").Collect(supplier, accumulate).Subscribe(onNext)
+	<-sub
+
+	assert.Equal(t, "foobarbaz", text)
+}
+
 func TestRepeatInfinityOperator(t *testing.T) {
 	myStream := Repeat("mystring")
 
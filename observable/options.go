@@ -0,0 +1,32 @@
+package observable
+
+// Option configures the Observable created by a constructor or an operator
+// accepting Options, such as From, Range, Map, Filter and Scan.
+type Option func(*options)
+
+type options struct {
+	bufferSize uint
+}
+
+// WithBufferSize sets the capacity of the channel of the new Observable,
+// trading memory for throughput: a stage with a buffer runs up to n items
+// ahead of its subscriber. The default of 0 makes every stage hand items
+// over one at a time, without loss.
+func WithBufferSize(n uint) Option {
+	return func(o *options) {
+		o.bufferSize = n
+	}
+}
+
+func applyOptions(opts []Option) options {
+	var o options
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+// newChannel creates the channel of a new Observable configured by opts.
+func newChannel(opts []Option) chan interface{} {
+	return make(chan interface{}, int(applyOptions(opts).bufferSize))
+}
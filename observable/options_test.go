@@ -0,0 +1,37 @@
+package observable
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/iterable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithBufferSize(t *testing.T) {
+	assert.Equal(t, 0, cap(newChannel(nil)))
+	assert.Equal(t, 16, cap(newChannel([]Option{WithBufferSize(16)})))
+
+	assert.Equal(t, 4, cap(Range(0, 10, WithBufferSize(4))))
+	assert.Equal(t, 4, cap(Just(1).Map(increment, WithBufferSize(4))))
+	assert.Equal(t, 4, cap(Just(1).Filter(func(interface{}) bool { return true }, WithBufferSize(4))))
+	assert.Equal(t, 4, cap(Just(1).Scan(func(acc, item interface{}) interface{} { return item }, WithBufferSize(4))))
+	assert.Equal(t, 4, cap(From(iterable.NewSlice([]interface{}{1}), WithBufferSize(4))))
+}
+
+func TestBufferedStageRunsAhead(t *testing.T) {
+	var mapped int32
+	o := Range(0, 10).Map(func(item interface{}) interface{} {
+		atomic.AddInt32(&mapped, 1)
+		return item
+	}, WithBufferSize(3))
+
+	// Three items fill the buffer and a fourth waits to be sent.
+	time.Sleep(20 * time.Millisecond)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&mapped))
+
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, items, 10)
+}
@@ -0,0 +1,104 @@
+package observable
+
+import "sync/atomic"
+
+// PausePolicy decides what a Pausable does with items arriving while paused.
+type PausePolicy uint32
+
+const (
+	// PauseBuffer keeps items arriving while paused and emits them on Resume.
+	PauseBuffer PausePolicy = iota
+
+	// PauseDrop discards items, but not errors, arriving while paused.
+	PauseDrop
+)
+
+// Pausable is an Observable whose emission can be paused and resumed.
+type Pausable struct {
+	Observable
+	control *pauseControl
+}
+
+type pauseControl struct {
+	paused int32
+	wake   chan struct{}
+}
+
+// Pause stops the Pausable from emitting until Resume is called.
+func (p Pausable) Pause() {
+	atomic.StoreInt32(&p.control.paused, 1)
+	p.control.signal()
+}
+
+// Resume restarts emission on a paused Pausable.
+func (p Pausable) Resume() {
+	atomic.StoreInt32(&p.control.paused, 0)
+	p.control.signal()
+}
+
+// Paused reports whether the Pausable is currently paused.
+func (p Pausable) Paused() bool {
+	return p.control.isPaused()
+}
+
+func (c *pauseControl) isPaused() bool {
+	return atomic.LoadInt32(&c.paused) == 1
+}
+
+func (c *pauseControl) signal() {
+	select {
+	case c.wake <- struct{}{}:
+	default:
+	}
+}
+
+// Pausable returns a Pausable emitting the items of the original Observable.
+// While paused, items are buffered or dropped according to an optional
+// PausePolicy, which defaults to PauseBuffer. An error is never dropped, but
+// held until Resume.
+func (o Observable) Pausable(policy ...PausePolicy) Pausable {
+	out := make(chan interface{})
+	register(out, o)
+	control := &pauseControl{wake: make(chan struct{}, 1)}
+	drop := len(policy) > 0 && policy[0] == PauseDrop
+
+	go func() {
+		var queue []interface{}
+		in := o
+		for in != nil || len(queue) > 0 {
+			paused := control.isPaused()
+
+			// Only pull from upstream while paused or when nothing is pending,
+			// so an active Pausable hands items through one at a time.
+			var source Observable
+			if paused || len(queue) == 0 {
+				source = in
+			}
+			var send chan<- interface{}
+			var next interface{}
+			if !paused && len(queue) > 0 {
+				send = out
+				next = queue[0]
+			}
+
+			select {
+			case item, ok := <-source:
+				if !ok {
+					in = nil
+					continue
+				}
+				if _, isErr := item.(error); !isErr && drop && control.isPaused() {
+					continue
+				}
+				queue = append(queue, item)
+			case send <- next:
+				queue[0] = nil
+				queue = queue[1:]
+			case <-control.wake:
+			}
+		}
+		closeStage(out)
+	}()
+
+	return Pausable{Observable: Observable(out), control: control}
+}
@@ -0,0 +1,83 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testPausable(t *testing.T, policy PausePolicy) []int {
+	source := make(chan interface{})
+	p := Observable(source).Pausable(policy)
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+	sub := p.Subscribe(onNext)
+
+	source <- 1
+	p.Pause()
+	assert.True(t, p.Paused())
+	source <- 2
+	source <- 3
+	<-time.After(10 * time.Millisecond)
+	p.Resume()
+	assert.False(t, p.Paused())
+	source <- 4
+	close(source)
+	<-sub
+
+	return nums
+}
+
+func TestPausableBuffer(t *testing.T) {
+	assert.Exactly(t, []int{1, 2, 3, 4}, testPausable(t, PauseBuffer))
+}
+
+func TestPausableDrop(t *testing.T) {
+	assert.Exactly(t, []int{1, 4}, testPausable(t, PauseDrop))
+}
+
+func TestPausableHoldsItemsWhilePaused(t *testing.T) {
+	p := Range(0, 3).Pausable()
+	p.Pause()
+
+	received := make(chan interface{}, 3)
+	sub := p.Subscribe(handlers.NextFunc(func(item interface{}) {
+		received <- item
+	}))
+
+	<-time.After(10 * time.Millisecond)
+	assert.Len(t, received, 0)
+
+	p.Resume()
+	<-sub
+	assert.Len(t, received, 3)
+}
+
+func TestPausableDropKeepsErrors(t *testing.T) {
+	source := make(chan interface{})
+	p := Observable(source).Pausable(PauseDrop)
+
+	var errText string
+	onError := handlers.ErrFunc(func(err error) {
+		errText = err.Error()
+	})
+	sub := p.Subscribe(onError)
+
+	p.Pause()
+	source <- 1
+	source <- errors.New("bang")
+	close(source)
+	<-time.After(10 * time.Millisecond)
+	p.Resume()
+	<-sub
+	assert.Equal(t, "bang", errText)
+}
@@ -0,0 +1,145 @@
+package observable
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/reactivex/rxgo/errors"
+)
+
+// p2 estimates a single quantile in constant memory using the P² algorithm
+// of Jain and Chlamtac, which tracks five markers whose heights approximate
+// the minimum, the p/2, p and (1+p)/2 quantiles, and the maximum.
+type p2 struct {
+	p       float64
+	count   int
+	heights [5]float64
+	pos     [5]float64
+	desired [5]float64
+	incr    [5]float64
+}
+
+func newP2(p float64) *p2 {
+	return &p2{
+		p:       p,
+		pos:     [5]float64{0, 1, 2, 3, 4},
+		desired: [5]float64{0, 2 * p, 4 * p, 2 + 2*p, 4},
+		incr:    [5]float64{0, p / 2, p, (1 + p) / 2, 1},
+	}
+}
+
+func (e *p2) add(x float64) {
+	if e.count < 5 {
+		e.heights[e.count] = x
+		e.count++
+		if e.count == 5 {
+			sort.Float64s(e.heights[:])
+		}
+		return
+	}
+	e.count++
+
+	var k int
+	switch {
+	case x < e.heights[0]:
+		e.heights[0] = x
+		k = 0
+	case x >= e.heights[4]:
+		e.heights[4] = x
+		k = 3
+	default:
+		for k = 0; x >= e.heights[k+1]; k++ {
+		}
+	}
+	for i := k + 1; i < 5; i++ {
+		e.pos[i]++
+	}
+	for i := range e.desired {
+		e.desired[i] += e.incr[i]
+	}
+
+	for i := 1; i < 4; i++ {
+		d := e.desired[i] - e.pos[i]
+		if (d >= 1 && e.pos[i+1]-e.pos[i] > 1) || (d <= -1 && e.pos[i-1]-e.pos[i] < -1) {
+			s := math.Copysign(1, d)
+			h := e.parabolic(i, s)
+			if e.heights[i-1] >= h || h >= e.heights[i+1] {
+				j := i + int(s)
+				h = e.heights[i] + s*(e.heights[j]-e.heights[i])/(e.pos[j]-e.pos[i])
+			}
+			e.heights[i] = h
+			e.pos[i] += s
+		}
+	}
+}
+
+func (e *p2) parabolic(i int, s float64) float64 {
+	q, n := e.heights, e.pos
+	return q[i] + s/(n[i+1]-n[i-1])*
+		((n[i]-n[i-1]+s)*(q[i+1]-q[i])/(n[i+1]-n[i])+
+			(n[i+1]-n[i]-s)*(q[i]-q[i-1])/(n[i]-n[i-1]))
+}
+
+// estimate returns the current quantile estimate, which is exact until five
+// numbers have been seen.
+func (e *p2) estimate() float64 {
+	if e.count >= 5 {
+		switch e.p {
+		case 0:
+			return e.heights[0]
+		case 1:
+			return e.heights[4]
+		}
+		return e.heights[2]
+	}
+	sorted := append([]float64(nil), e.heights[:e.count]...)
+	sort.Float64s(sorted)
+	rank := e.p * float64(len(sorted)-1)
+	lo := int(math.Floor(rank))
+	hi := int(math.Ceil(rank))
+	return sorted[lo] + (rank-float64(lo))*(sorted[hi]-sorted[lo])
+}
+
+// Quantile emits a float64 estimate of the q quantile (for example 0.99 for
+// p99) of the numbers in the original Observable once it completes, or
+// nothing if it is empty. The estimate is maintained in constant memory, so
+// no values are stored. If every is specified the running estimate is also
+// emitted after every that many numbers.
+func (o Observable) Quantile(q float64, every ...uint) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		defer closeStage(out)
+		if q < 0 || q > 1 {
+			out <- errors.New(errors.ObservableError, fmt.Sprintf("quantile %v is outside [0, 1]", q))
+			return
+		}
+		var n uint
+		if len(every) > 0 {
+			n = every[0]
+		}
+		estimator := newP2(q)
+		pending := uint(0)
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				return
+			}
+			num, err := toNumeric(item)
+			if err != nil {
+				out <- err
+				return
+			}
+			estimator.add(num.float)
+			if pending++; pending == n {
+				out <- estimator.estimate()
+				pending = 0
+			}
+		}
+		if estimator.count > 0 && (n == 0 || pending > 0) {
+			out <- estimator.estimate()
+		}
+	}()
+	return describe(Observable(out), "Quantile", map[string]interface{}{"q": q}, o)
+}
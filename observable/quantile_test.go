@@ -0,0 +1,37 @@
+package observable
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableQuantile(t *testing.T) {
+	values := rand.New(rand.NewSource(42)).Perm(10000)
+	items := make([]interface{}, len(values))
+	for i, v := range values {
+		items[i] = v
+	}
+
+	p95, err := Just(items[0], items[1:]...).Quantile(0.95).BlockingSingle()
+	assert.Nil(t, err)
+	assert.InDelta(t, 9500, p95, 100)
+
+	median, err := Just(items[0], items[1:]...).Quantile(0.5).BlockingSingle()
+	assert.Nil(t, err)
+	assert.InDelta(t, 5000, median, 100)
+}
+
+func TestObservableQuantileSmall(t *testing.T) {
+	median, err := Just(3, 1, 2).Quantile(0.5).BlockingSingle()
+	assert.Nil(t, err)
+	assert.Equal(t, 2.0, median)
+
+	estimates, err := Range(0, 10).Quantile(1, 4).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{3.0, 7.0, 9.0}, estimates)
+
+	_, err = Just(1).Quantile(1.5).ToSlice()
+	assert.Error(t, err)
+}
@@ -0,0 +1,112 @@
+package observable
+
+import (
+	"encoding/gob"
+	"io"
+	"time"
+)
+
+// recording is a signal written by Record, with its time relative to the
+// start of the recording.
+type recording struct {
+	Offset time.Duration
+	Kind   NotificationKind
+	Item   interface{}
+	Err    string
+}
+
+// recordedError is an error read back from a recording.
+type recordedError string
+
+func (e recordedError) Error() string {
+	return string(e)
+}
+
+// Record passes the items of the original Observable through unchanged
+// while writing every signal to w, along with its time relative to the
+// first one read from an optional Clock, so that ReplayRecording can
+// reproduce the sequence later. Signals are gob-encoded, so items of types
+// other than the predeclared ones must be registered with gob.Register.
+// If a signal can't be written, the new Observable emits the write error
+// and stops.
+func (o Observable) Record(w io.Writer, clock ...Clock) Observable {
+	c := clockOrDefault(clock)
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		defer closeStage(out)
+		enc := gob.NewEncoder(w)
+		start := c.Now()
+		write := func(r recording) bool {
+			r.Offset = c.Now().Sub(start)
+			if err := enc.Encode(&r); err != nil {
+				out <- err
+				return false
+			}
+			return true
+		}
+
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				if write(recording{Kind: ErrorNotification, Err: err.Error()}) {
+					out <- item
+				}
+				return
+			}
+			if !write(recording{Kind: NextNotification, Item: item}) {
+				return
+			}
+			out <- item
+		}
+		write(recording{Kind: DoneNotification})
+	}()
+	return describe(Observable(out), "Record", nil, o)
+}
+
+// ReplayRecording creates an Observable reproducing the signals written by
+// Record to r, with their relative timing. An optional speed scales the
+// timing: 2 replays twice as fast, and 0 replays without any delay. Errors
+// are replayed with their original message. A recording which can't be
+// read ends with the read error.
+func ReplayRecording(r io.Reader, speed ...float64) Observable {
+	factor := 1.0
+	if len(speed) > 0 {
+		factor = speed[0]
+	}
+	out := make(chan interface{})
+	s := register(out)
+	go func() {
+		defer closeStage(out)
+		dec := gob.NewDecoder(r)
+		start := time.Now()
+		for !s.isCancelled() {
+			var rec recording
+			if err := dec.Decode(&rec); err != nil {
+				if err != io.EOF {
+					out <- err
+				}
+				return
+			}
+			if factor > 0 {
+				due := start.Add(time.Duration(float64(rec.Offset) / factor))
+				if wait := time.Until(due); wait > 0 {
+					select {
+					case <-time.After(wait):
+					case <-s.cancelled():
+						return
+					}
+				}
+			}
+			switch rec.Kind {
+			case ErrorNotification:
+				out <- recordedError(rec.Err)
+				return
+			case DoneNotification:
+				return
+			default:
+				out <- rec.Item
+			}
+		}
+	}()
+	return created("ReplayRecording", Observable(out))
+}
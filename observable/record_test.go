@@ -0,0 +1,61 @@
+package observable
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRecordAndReplay(t *testing.T) {
+	var buf bytes.Buffer
+	clock := NewManualClock(time.Unix(0, 0))
+	source := make(chan interface{})
+	o := Observable(source).Record(&buf, clock)
+
+	var items []interface{}
+	for _, item := range []interface{}{1, "two", 3.0} {
+		source <- item
+		items = append(items, <-o)
+		clock.Advance(10 * time.Millisecond)
+	}
+	close(source)
+	_, ok := <-o
+	assert.False(t, ok)
+	assert.Equal(t, []interface{}{1, "two", 3.0}, items)
+
+	start := time.Now()
+	items, err := ReplayRecording(bytes.NewReader(buf.Bytes())).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, "two", 3.0}, items)
+	assert.True(t, time.Since(start) >= 30*time.Millisecond)
+
+	start = time.Now()
+	items, _ = ReplayRecording(bytes.NewReader(buf.Bytes()), 0).ToSlice()
+	assert.Len(t, items, 3)
+	assert.True(t, time.Since(start) < 30*time.Millisecond)
+}
+
+func TestRecordError(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := Just(1, errors.New("bang"), 2).Record(&buf).ToSlice()
+	assert.EqualError(t, err, "bang")
+
+	items, err := ReplayRecording(&buf, 0).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestRecordUnregisteredType(t *testing.T) {
+	var buf bytes.Buffer
+	type unregistered struct{ A int }
+	_, err := Just(unregistered{1}).Record(&buf).ToSlice()
+	assert.NotNil(t, err)
+}
+
+func TestReplayRecordingCorrupted(t *testing.T) {
+	_, err := ReplayRecording(bytes.NewReader([]byte("garbage"))).ToSlice()
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,289 @@
+package observable
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/gob"
+	"sync"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// ReplayCodec encodes buffered items into bytes and back, for example to
+// compress them while they are retained by Replay.
+type ReplayCodec interface {
+	Encode(item interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// ReplayOption configures Replay.
+type ReplayOption func(*replayConfig)
+
+type replayConfig struct {
+	maxBytes uint
+	size     fx.SizeFunc
+	codec    ReplayCodec
+	onEvict  func(interface{})
+}
+
+// ReplayMaxBytes bounds the items retained for late subscribers to n bytes,
+// evicting the oldest ones first. 0, the default, retains every item. The
+// limit doesn't apply to the items a subscriber hasn't received yet, which
+// are kept until it does.
+func ReplayMaxBytes(n uint) ReplayOption {
+	return func(c *replayConfig) {
+		c.maxBytes = n
+	}
+}
+
+// ReplaySize sets how the size of an item is measured. By default strings
+// and byte slices count their length and other items 8 bytes. Items
+// encoded by a ReplayCodec count their encoded length instead.
+func ReplaySize(size fx.SizeFunc) ReplayOption {
+	return func(c *replayConfig) {
+		c.size = size
+	}
+}
+
+// ReplayCompression retains items encoded by codec, and decodes them when
+// they are replayed.
+func ReplayCompression(codec ReplayCodec) ReplayOption {
+	return func(c *replayConfig) {
+		c.codec = codec
+	}
+}
+
+// ReplayOnEvict sets a callback called with each item evicted to honour
+// ReplayMaxBytes, which won't be replayed to new subscribers anymore.
+func ReplayOnEvict(onEvict func(interface{})) ReplayOption {
+	return func(c *replayConfig) {
+		c.onEvict = onEvict
+	}
+}
+
+func defaultItemSize(item interface{}) uint {
+	switch item := item.(type) {
+	case string:
+		return uint(len(item))
+	case []byte:
+		return uint(len(item))
+	default:
+		return 8
+	}
+}
+
+// replayEntry is a retained item, stored either as is or encoded.
+type replayEntry struct {
+	item    interface{}
+	encoded []byte
+	size    uint
+}
+
+// Replay multicasts an Observable, retaining its items so that each
+// subscriber receives the retained items before the live ones.
+type Replay struct {
+	config replayConfig
+
+	mu      sync.Mutex
+	cond    *sync.Cond
+	entries []replayEntry
+	// base is the sequence number of entries[0], and start the one of the
+	// first item replayed to new subscribers. The entries before start are
+	// only kept for the subscribers which haven't received them yet.
+	base  uint64
+	start uint64
+	// cursors holds the sequence number of the next item of each
+	// subscriber.
+	cursors map[*uint64]struct{}
+	bytes   uint
+	err     error
+	done    bool
+}
+
+// Replay starts consuming the Observable immediately and retains its items
+// for the subscribers of the returned Replay, within the limits set by
+// opts.
+func (o Observable) Replay(opts ...ReplayOption) *Replay {
+	r := &Replay{config: replayConfig{size: defaultItemSize}, cursors: make(map[*uint64]struct{})}
+	for _, opt := range opts {
+		opt(&r.config)
+	}
+	r.cond = sync.NewCond(&r.mu)
+
+	go func() {
+		for item := range o {
+			if err, ok := item.(error); ok {
+				r.mu.Lock()
+				r.err = err
+				r.mu.Unlock()
+				break
+			}
+			r.add(item)
+		}
+		r.mu.Lock()
+		r.done = true
+		r.mu.Unlock()
+		r.cond.Broadcast()
+	}()
+	return r
+}
+
+// Cache is Replay without any limit: every item is retained.
+func (o Observable) Cache() *Replay {
+	return o.Replay()
+}
+
+func (r *Replay) add(item interface{}) {
+	entry := replayEntry{item: item}
+	if r.config.codec != nil {
+		if data, err := r.config.codec.Encode(item); err == nil {
+			entry = replayEntry{encoded: data, size: uint(len(data))}
+		}
+	}
+	if entry.encoded == nil {
+		entry.size = r.config.size(item)
+	}
+
+	var evicted []replayEntry
+	r.mu.Lock()
+	r.entries = append(r.entries, entry)
+	r.bytes += entry.size
+	end := r.base + uint64(len(r.entries))
+	for r.config.maxBytes > 0 && r.bytes > r.config.maxBytes && r.start < end {
+		e := r.entries[r.start-r.base]
+		evicted = append(evicted, e)
+		r.bytes -= e.size
+		r.start++
+	}
+	r.trim()
+	r.mu.Unlock()
+	r.cond.Broadcast()
+
+	if r.config.onEvict != nil {
+		for _, e := range evicted {
+			r.config.onEvict(r.decode(e))
+		}
+	}
+}
+
+// trim drops the entries neither replayed to new subscribers nor awaited by
+// a current one. r.mu must be held.
+func (r *Replay) trim() {
+	low := r.start
+	for cursor := range r.cursors {
+		if *cursor < low {
+			low = *cursor
+		}
+	}
+	for ; r.base < low; r.base++ {
+		r.entries[0] = replayEntry{}
+		r.entries = r.entries[1:]
+	}
+}
+
+func (r *Replay) decode(e replayEntry) interface{} {
+	if e.encoded == nil {
+		return e.item
+	}
+	item, err := r.config.codec.Decode(e.encoded)
+	if err != nil {
+		return err
+	}
+	return item
+}
+
+// Bytes returns the size of the retained items.
+func (r *Replay) Bytes() uint {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bytes
+}
+
+// Len returns the number of retained items.
+func (r *Replay) Len() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return int(r.base + uint64(len(r.entries)) - r.start)
+}
+
+// Subscribe returns an Observable of the retained items followed by the
+// live ones. A subscriber receives every item emitted after it subscribed,
+// however far behind it falls: the items it hasn't received yet are kept
+// for it, beyond ReplayMaxBytes if need be.
+func (r *Replay) Subscribe() Observable {
+	out := make(chan interface{})
+	r.mu.Lock()
+	next := r.start
+	r.cursors[&next] = struct{}{}
+	r.mu.Unlock()
+
+	st := register(out)
+	st.onCancel = func() {
+		r.mu.Lock()
+		r.cond.Broadcast()
+		r.mu.Unlock()
+	}
+	go func() {
+		for {
+			r.mu.Lock()
+			for next >= r.base+uint64(len(r.entries)) && !r.done && !st.isCancelled() {
+				r.cond.Wait()
+			}
+			if next >= r.base+uint64(len(r.entries)) || st.isCancelled() {
+				delete(r.cursors, &next)
+				r.trim()
+				err := r.err
+				r.mu.Unlock()
+				if err != nil && !st.isCancelled() {
+					out <- err
+				}
+				closeStage(out)
+				return
+			}
+			entry := r.entries[next-r.base]
+			next++
+			r.trim()
+			r.mu.Unlock()
+
+			out <- r.decode(entry)
+		}
+	}()
+	return Observable(out)
+}
+
+// flateGob is a ReplayCodec compressing gob-encoded items with DEFLATE.
+type flateGob struct {
+	level int
+}
+
+// FlateGobCodec returns a ReplayCodec encoding items with encoding/gob and
+// compressing them with compress/flate at the given level. Items of types
+// other than the predeclared ones must be registered with gob.Register.
+func FlateGobCodec(level int) ReplayCodec {
+	return flateGob{level: level}
+}
+
+func (c flateGob) Encode(item interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, c.level)
+	if err != nil {
+		return nil, err
+	}
+	if err := gob.NewEncoder(w).Encode(&item); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+func (c flateGob) Decode(data []byte) (interface{}, error) {
+	r := flate.NewReader(bytes.NewReader(data))
+	defer r.Close()
+	var item interface{}
+	if err := gob.NewDecoder(r).Decode(&item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
@@ -0,0 +1,106 @@
+package observable
+
+import (
+	"compress/flate"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReplay(t *testing.T) {
+	r := Just(1, 2, 3).Replay()
+	for i := 0; i < 2; i++ {
+		items, err := r.Subscribe().ToSlice()
+		assert.Nil(t, err)
+		assert.Equal(t, []interface{}{1, 2, 3}, items)
+	}
+	assert.Equal(t, 3, r.Len())
+	assert.Equal(t, uint(24), r.Bytes())
+}
+
+func TestReplayError(t *testing.T) {
+	r := Just(1, errors.New("bang"), 2).Cache()
+	items, err := r.Subscribe().ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestReplayMaxBytes(t *testing.T) {
+	var evicted []interface{}
+	source := make(chan interface{})
+	r := Observable(source).Replay(
+		ReplayMaxBytes(6),
+		ReplayOnEvict(func(item interface{}) { evicted = append(evicted, item) }),
+	)
+	live := r.Subscribe()
+	for _, s := range []string{"ab", "cd", "ef", "gh"} {
+		source <- s
+		assert.Equal(t, s, <-live)
+	}
+	close(source)
+	_, ok := <-live
+	assert.False(t, ok)
+
+	items, err := r.Subscribe().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"cd", "ef", "gh"}, items)
+	assert.Equal(t, []interface{}{"ab"}, evicted)
+	assert.Equal(t, uint(6), r.Bytes())
+}
+
+func TestReplayMaxBytesLiveSubscriber(t *testing.T) {
+	source := make(chan interface{})
+	r := Observable(source).Replay(ReplayMaxBytes(4))
+	live := r.Subscribe()
+	sent := []interface{}{"ab", "cd", "a much larger item", "ef", "gh"}
+	for _, s := range sent {
+		source <- s
+	}
+	close(source)
+
+	items, err := live.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, sent, items)
+
+	items, _ = r.Subscribe().ToSlice()
+	assert.Equal(t, []interface{}{"ef", "gh"}, items)
+	assert.Equal(t, 2, r.Len())
+}
+
+func TestReplaySize(t *testing.T) {
+	r := Just(1, 2, 3).Replay(
+		ReplayMaxBytes(2),
+		ReplaySize(func(interface{}) uint { return 1 }),
+	)
+	items, err := r.Subscribe().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{2, 3}, items[len(items)-2:])
+
+	items, _ = r.Subscribe().ToSlice()
+	assert.Equal(t, []interface{}{2, 3}, items)
+}
+
+func TestReplayCompression(t *testing.T) {
+	payload := strings.Repeat("rxgo ", 200)
+	codec := FlateGobCodec(flate.BestCompression)
+	encoded, err := codec.Encode(payload)
+	assert.Nil(t, err)
+	assert.True(t, len(encoded) < len(payload)/10)
+
+	var evicted []interface{}
+	r := Just(payload, payload).Replay(
+		ReplayCompression(codec),
+		ReplayMaxBytes(uint(len(encoded))),
+		ReplayOnEvict(func(item interface{}) { evicted = append(evicted, item) }),
+	)
+	items, err := r.Subscribe().ToSlice()
+	assert.Nil(t, err)
+	assert.Contains(t, items, payload)
+
+	items, _ = r.Subscribe().ToSlice()
+	assert.Equal(t, []interface{}{payload}, items)
+	assert.Equal(t, []interface{}{payload}, evicted)
+	assert.Equal(t, uint(len(encoded)), r.Bytes())
+}
@@ -0,0 +1,90 @@
+package observable
+
+import "github.com/reactivex/rxgo/fx"
+
+// Result is an item or the error its computation failed with, carried as
+// data so that a per-item failure doesn't terminate the stream.
+type Result struct {
+	Value interface{}
+	Err   error
+}
+
+// IsError reports whether the Result holds an error.
+func (r Result) IsError() bool {
+	return r.Err != nil
+}
+
+// Get returns the value and error of the Result.
+func (r Result) Get() (interface{}, error) {
+	return r.Value, r.Err
+}
+
+// MapValue applies apply to the value of a successful Result, and returns a
+// failed Result unchanged.
+func (r Result) MapValue(apply fx.MappableFunc) Result {
+	if r.IsError() {
+		return r
+	}
+	return Result{Value: apply(r.Value)}
+}
+
+// Results wraps every item of the original Observable in a Result, errors
+// included, and carries on past errors.
+func (o Observable) Results() Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				out <- Result{Err: err}
+			} else {
+				out <- Result{Value: item}
+			}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "Results", nil, o)
+}
+
+// MapResult maps a fallible MappableErrFunc predicate to each item in the
+// original Observable and emits the outcome as a Result, so that failures
+// flow downstream as data. Errors emitted by the original Observable are
+// wrapped in a Result too.
+func (o Observable) MapResult(apply fx.MappableErrFunc) Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				out <- Result{Err: err}
+				continue
+			}
+			value, err := apply(item)
+			out <- Result{Value: value, Err: err}
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "MapResult", nil, o)
+}
+
+// UnwrapResults turns the Results emitted by the original Observable back
+// into items and errors, so that the first failed Result terminates
+// subscribers. Items which aren't Results are emitted as they are.
+func (o Observable) UnwrapResults() Observable {
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if result, ok := item.(Result); ok {
+				if result.IsError() {
+					item = result.Err
+				} else {
+					item = result.Value
+				}
+			}
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "UnwrapResults", nil, o)
+}
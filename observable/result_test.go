@@ -0,0 +1,47 @@
+package observable
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResult(t *testing.T) {
+	double := func(item interface{}) interface{} {
+		return item.(int) * 2
+	}
+
+	ok := Result{Value: 2}
+	assert.False(t, ok.IsError())
+	assert.Equal(t, Result{Value: 4}, ok.MapValue(double))
+
+	failed := Result{Err: errors.New("bang")}
+	assert.True(t, failed.IsError())
+	assert.Equal(t, failed, failed.MapValue(double))
+	_, err := failed.Get()
+	assert.EqualError(t, err, "bang")
+}
+
+func TestObservableMapResult(t *testing.T) {
+	bang := errors.New("bang")
+	items, err := Just("1", "x", "3").MapResult(func(item interface{}) (interface{}, error) {
+		return strconv.Atoi(item.(string))
+	}).ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, items, 3)
+	assert.Equal(t, Result{Value: 1}, items[0])
+	assert.True(t, items[1].(Result).IsError())
+	assert.Equal(t, Result{Value: 3}, items[2])
+
+	items, err = Just(1, bang, 2).Results().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{Result{Value: 1}, Result{Err: bang}, Result{Value: 2}}, items)
+}
+
+func TestObservableUnwrapResults(t *testing.T) {
+	items, err := Just(Result{Value: 1}, 2, Result{Err: errors.New("bang")}, 3).UnwrapResults().ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []interface{}{1, 2}, items)
+}
@@ -0,0 +1,114 @@
+package observable
+
+import (
+	"runtime"
+	"sync/atomic"
+)
+
+// ringSpins is how many times a full or empty ring yields before parking.
+const ringSpins = 64
+
+// spscRing is a lock-free ring buffer handing items from a single producer
+// goroutine to a single consumer goroutine. Both sides spin briefly on a
+// full or empty ring, then park until the other side signals them.
+//
+// It backs the queues a stage owns at both ends, those of ObserveOn and
+// Pipeline. The queue between two stages is the channel of an Observable,
+// which is the public type, so it stays a channel; chained synchronous
+// stages avoid it altogether by being fused.
+type spscRing struct {
+	buf  []interface{}
+	mask uint64
+
+	_    [56]byte // keep head and tail on separate cache lines
+	head uint64   // next slot to read, owned by the consumer
+	_    [56]byte
+	tail uint64 // next slot to write, owned by the producer
+	_    [56]byte
+
+	closed          uint32
+	producerWaiting uint32
+	consumerWaiting uint32
+	notFull         chan struct{}
+	notEmpty        chan struct{}
+}
+
+// newSPSCRing creates a spscRing holding at least capacity items.
+func newSPSCRing(capacity uint) *spscRing {
+	size := uint64(1)
+	for size < uint64(capacity) {
+		size <<= 1
+	}
+	return &spscRing{
+		buf:      make([]interface{}, size),
+		mask:     size - 1,
+		notFull:  make(chan struct{}, 1),
+		notEmpty: make(chan struct{}, 1),
+	}
+}
+
+func wake(ch chan struct{}) {
+	select {
+	case ch <- struct{}{}:
+	default:
+	}
+}
+
+// put appends item, blocking while the ring is full. Only the producer may
+// call it.
+func (r *spscRing) put(item interface{}) {
+	tail := r.tail
+	for spins := 0; tail-atomic.LoadUint64(&r.head) > r.mask; spins++ {
+		if spins < ringSpins {
+			runtime.Gosched()
+			continue
+		}
+		atomic.StoreUint32(&r.producerWaiting, 1)
+		if tail-atomic.LoadUint64(&r.head) > r.mask {
+			<-r.notFull
+		}
+		atomic.StoreUint32(&r.producerWaiting, 0)
+	}
+	r.buf[tail&r.mask] = item
+	atomic.StoreUint64(&r.tail, tail+1)
+	if atomic.LoadUint32(&r.consumerWaiting) == 1 {
+		wake(r.notEmpty)
+	}
+}
+
+// close marks the end of the items. Only the producer may call it.
+func (r *spscRing) close() {
+	atomic.StoreUint32(&r.closed, 1)
+	wake(r.notEmpty)
+}
+
+// get removes the oldest item, blocking while the ring is empty. It returns
+// false once the ring is closed and drained. Only the consumer may call it.
+func (r *spscRing) get() (interface{}, bool) {
+	head := r.head
+	for spins := 0; atomic.LoadUint64(&r.tail) == head; spins++ {
+		if atomic.LoadUint32(&r.closed) == 1 {
+			// The producer may have put a last item before closing.
+			if atomic.LoadUint64(&r.tail) == head {
+				return nil, false
+			}
+			break
+		}
+		if spins < ringSpins {
+			runtime.Gosched()
+			continue
+		}
+		atomic.StoreUint32(&r.consumerWaiting, 1)
+		if atomic.LoadUint64(&r.tail) == head && atomic.LoadUint32(&r.closed) == 0 {
+			<-r.notEmpty
+		}
+		atomic.StoreUint32(&r.consumerWaiting, 0)
+	}
+	item := r.buf[head&r.mask]
+	r.buf[head&r.mask] = nil
+	atomic.StoreUint64(&r.head, head+1)
+	if atomic.LoadUint32(&r.producerWaiting) == 1 {
+		wake(r.notFull)
+	}
+	return item, true
+}
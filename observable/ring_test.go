@@ -0,0 +1,100 @@
+package observable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSPSCRingOrder(t *testing.T) {
+	r := newSPSCRing(8)
+	const n = 100000
+	go func() {
+		for i := 0; i < n; i++ {
+			r.put(i)
+		}
+		r.close()
+	}()
+
+	for i := 0; i < n; i++ {
+		item, ok := r.get()
+		assert.True(t, ok)
+		if item != i {
+			assert.Equal(t, i, item)
+			return
+		}
+	}
+	_, ok := r.get()
+	assert.False(t, ok)
+}
+
+func TestSPSCRingBlocksWhenFull(t *testing.T) {
+	r := newSPSCRing(2)
+	r.put(1)
+	r.put(2)
+
+	put := make(chan struct{})
+	go func() {
+		r.put(3)
+		close(put)
+	}()
+	select {
+	case <-put:
+		assert.Fail(t, "put on a full ring did not block")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	item, _ := r.get()
+	assert.Equal(t, 1, item)
+	<-put
+	item, _ = r.get()
+	assert.Equal(t, 2, item)
+	item, _ = r.get()
+	assert.Equal(t, 3, item)
+}
+
+func TestSPSCRingWakesParkedConsumer(t *testing.T) {
+	r := newSPSCRing(4)
+	got := make(chan interface{})
+	go func() {
+		item, _ := r.get()
+		got <- item
+		_, ok := r.get()
+		got <- ok
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	r.put("late")
+	assert.Equal(t, "late", <-got)
+	time.Sleep(20 * time.Millisecond)
+	r.close()
+	assert.Equal(t, false, <-got)
+}
+
+func BenchmarkSPSCRing(b *testing.B) {
+	r := newSPSCRing(1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			r.put(i)
+		}
+		r.close()
+	}()
+	for {
+		if _, ok := r.get(); !ok {
+			break
+		}
+	}
+}
+
+func BenchmarkBufferedChannel(b *testing.B) {
+	ch := make(chan interface{}, 1024)
+	go func() {
+		for i := 0; i < b.N; i++ {
+			ch <- i
+		}
+		close(ch)
+	}()
+	for range ch {
+	}
+}
@@ -0,0 +1,85 @@
+//go:build go1.23
+
+package observable
+
+import "iter"
+
+// KeyValue is a pair of values yielded by an iter.Seq2.
+type KeyValue struct {
+	Key   interface{}
+	Value interface{}
+}
+
+// FromSeq creates an Observable from a range-over-func iterator. The iterator
+// is advanced lazily, one item ahead of the subscriber.
+func FromSeq[T any](seq iter.Seq[T]) Observable {
+	source := make(chan interface{})
+	s := register(source)
+	go func() {
+		for item := range seq {
+			if s.isCancelled() {
+				break
+			}
+			source <- item
+		}
+		closeStage(source)
+	}()
+	return created("FromSeq", Observable(source))
+}
+
+// FromSeq2 creates an Observable emitting a KeyValue for each pair yielded by
+// a range-over-func iterator. The iterator is advanced lazily, one pair ahead
+// of the subscriber.
+func FromSeq2[K, V any](seq iter.Seq2[K, V]) Observable {
+	source := make(chan interface{})
+	s := register(source)
+	go func() {
+		for key, value := range seq {
+			if s.isCancelled() {
+				break
+			}
+			source <- KeyValue{Key: key, Value: value}
+		}
+		closeStage(source)
+	}()
+	return created("FromSeq2", Observable(source))
+}
+
+// ToSeq returns a range-over-func iterator over the items of the original
+// Observable. Iteration stops at the first error, which is discarded; use
+// ToSeq2 to observe it. Breaking out of the range loop unsubscribes from the
+// Observable.
+func (o Observable) ToSeq() iter.Seq[interface{}] {
+	return func(yield func(interface{}) bool) {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				release(o)
+				return
+			}
+			if !yield(item) {
+				release(o)
+				return
+			}
+		}
+	}
+}
+
+// ToSeq2 returns a range-over-func iterator yielding each item of the original
+// Observable with a nil error. If the Observable fails, a final nil item is
+// yielded with the error which terminated it. Breaking out of the range loop
+// unsubscribes from the Observable.
+func (o Observable) ToSeq2() iter.Seq2[interface{}, error] {
+	return func(yield func(interface{}, error) bool) {
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				release(o)
+				yield(nil, err)
+				return
+			}
+			if !yield(item, nil) {
+				release(o)
+				return
+			}
+		}
+	}
+}
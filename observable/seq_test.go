@@ -0,0 +1,96 @@
+//go:build go1.23
+
+package observable
+
+import (
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSeqOperator(t *testing.T) {
+	words := []string{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if word, ok := item.(string); ok {
+			words = append(words, word)
+		}
+	})
+
+	sub := FromSeq(slices.Values([]string{"foo", "bar"})).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []string{"foo", "bar"}, words)
+}
+
+func TestFromSeqIsLazy(t *testing.T) {
+	pulled := make(chan int, 10)
+	seq := func(yield func(int) bool) {
+		for i := 0; i < 10; i++ {
+			pulled <- i
+			if !yield(i) {
+				return
+			}
+		}
+	}
+
+	myStream := FromSeq(seq)
+	<-time.After(10 * time.Millisecond)
+	assert.Len(t, pulled, 1)
+
+	sub := myStream.Subscribe(handlers.NextFunc(func(interface{}) {}))
+	<-sub
+	assert.Len(t, pulled, 10)
+}
+
+func TestFromSeq2Operator(t *testing.T) {
+	pairs := []KeyValue{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if pair, ok := item.(KeyValue); ok {
+			pairs = append(pairs, pair)
+		}
+	})
+
+	sub := FromSeq2(maps.All(map[string]int{"foo": 1})).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []KeyValue{{"foo", 1}}, pairs)
+}
+
+func TestObservableToSeq(t *testing.T) {
+	nums := []int{}
+	for item := range Range(0, 10).ToSeq() {
+		if item.(int) == 3 {
+			break
+		}
+		nums = append(nums, item.(int))
+	}
+
+	assert.Exactly(t, []int{0, 1, 2}, nums)
+}
+
+func TestObservableToSeqWithError(t *testing.T) {
+	items := slices.Collect(Just(1, errors.New("bang"), 2).ToSeq())
+
+	assert.Exactly(t, []interface{}{1}, items)
+}
+
+func TestObservableToSeq2(t *testing.T) {
+	items := []interface{}{}
+	var failure error
+	for item, err := range Just(1, 2, errors.New("bang")).ToSeq2() {
+		if err != nil {
+			failure = err
+			break
+		}
+		items = append(items, item)
+	}
+
+	assert.Exactly(t, []interface{}{1, 2}, items)
+	assert.EqualError(t, failure, "bang")
+}
@@ -0,0 +1,181 @@
+package observable
+
+import (
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/fx"
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Single is a stream of exactly one item or an error, such as the response
+// to a request.
+type Single <-chan interface{}
+
+// Maybe is a stream of at most one item or an error.
+type Maybe <-chan interface{}
+
+// SingleOf creates a Single emitting item.
+func SingleOf(item interface{}) Single {
+	return Single(one(item))
+}
+
+// SingleError creates a Single failing with err.
+func SingleError(err error) Single {
+	return Single(one(err))
+}
+
+// SingleFrom creates a Single emitting the result of f, which is called in
+// its own goroutine.
+func SingleFrom(f func() (interface{}, error)) Single {
+	out := make(chan interface{}, 1)
+	go func() {
+		item, err := f()
+		if err != nil {
+			out <- err
+		} else {
+			out <- item
+		}
+		close(out)
+	}()
+	return Single(out)
+}
+
+// Single converts the original Observable into a Single. It fails with a
+// NoSuchElementError if the Observable is empty, and with an ObservableError
+// if it emits more than one item.
+func (o Observable) Single() Single {
+	out := make(chan interface{}, 1)
+	register(out, o)
+	go func() {
+		item, err := o.BlockingSingle()
+		if err != nil {
+			out <- err
+		} else {
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return Single(out)
+}
+
+// Observable converts the Single into an Observable.
+func (s Single) Observable() Observable {
+	return Observable(s)
+}
+
+// Get blocks until the Single terminates and returns its item or error.
+func (s Single) Get() (interface{}, error) {
+	return Observable(s).BlockingSingle()
+}
+
+// Map maps the item of the Single with apply.
+func (s Single) Map(apply fx.MappableFunc) Single {
+	return Single(Observable(s).Map(func(item interface{}) interface{} {
+		if _, isErr := item.(error); isErr {
+			return item
+		}
+		return apply(item)
+	}))
+}
+
+// Subscribe subscribes handlers to the item or the error of the Single.
+// Either of them may be nil.
+func (s Single) Subscribe(onSuccess handlers.NextFunc, onError handlers.ErrFunc) <-chan subscription.Subscription {
+	return Observable(s).SubscribeAll(onSuccess, onError, nil)
+}
+
+// MaybeOf creates a Maybe emitting item.
+func MaybeOf(item interface{}) Maybe {
+	return Maybe(one(item))
+}
+
+// EmptyMaybe creates a Maybe completing without an item.
+func EmptyMaybe() Maybe {
+	out := make(chan interface{})
+	close(out)
+	return Maybe(out)
+}
+
+// MaybeError creates a Maybe failing with err.
+func MaybeError(err error) Maybe {
+	return Maybe(one(err))
+}
+
+// Maybe converts the original Observable into a Maybe. It fails with an
+// ObservableError if the Observable emits more than one item.
+func (o Observable) Maybe() Maybe {
+	out := make(chan interface{}, 1)
+	register(out, o)
+	go func() {
+		item, err := o.BlockingSingle()
+		switch {
+		case isNoSuchElement(err):
+		case err != nil:
+			out <- err
+		default:
+			out <- item
+		}
+		closeStage(out)
+	}()
+	return Maybe(out)
+}
+
+// Observable converts the Maybe into an Observable.
+func (m Maybe) Observable() Observable {
+	return Observable(m)
+}
+
+// Get blocks until the Maybe terminates and returns its item, if any, or its
+// error.
+func (m Maybe) Get() (interface{}, bool, error) {
+	item, err := Observable(m).BlockingSingle()
+	if isNoSuchElement(err) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return item, true, nil
+}
+
+// Map maps the item of the Maybe, if any, with apply.
+func (m Maybe) Map(apply fx.MappableFunc) Maybe {
+	return Maybe(Single(m).Map(apply))
+}
+
+// ToSingle converts the Maybe into a Single, emitting def if the Maybe is
+// empty, or failing with a NoSuchElementError if no default is given.
+func (m Maybe) ToSingle(def ...interface{}) Single {
+	out := make(chan interface{}, 1)
+	register(out, Observable(m))
+	go func() {
+		item, ok, err := m.Get()
+		switch {
+		case err != nil:
+			out <- err
+		case ok:
+			out <- item
+		case len(def) > 0:
+			out <- def[0]
+		default:
+			out <- errors.New(errors.NoSuchElementError)
+		}
+		closeStage(out)
+	}()
+	return Single(out)
+}
+
+// Subscribe subscribes handlers to the item, the error or the empty
+// completion of the Maybe. Any of them may be nil.
+func (m Maybe) Subscribe(onSuccess handlers.NextFunc, onError handlers.ErrFunc, onEmpty handlers.DoneFunc) <-chan subscription.Subscription {
+	empty := true
+	var onNext handlers.NextFunc = func(item interface{}) {
+		empty = false
+		onSuccess.Handle(item)
+	}
+	return Observable(m).SubscribeAll(onNext, onError, func() {
+		if empty {
+			onEmpty.Handle(nil)
+		}
+	})
+}
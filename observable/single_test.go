@@ -0,0 +1,103 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+
+	rxerrors "github.com/reactivex/rxgo/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSingle(t *testing.T) {
+	item, err := SingleOf(2).Map(func(item interface{}) interface{} {
+		return item.(int) * 10
+	}).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 20, item)
+
+	_, err = SingleError(errors.New("bang")).Map(func(item interface{}) interface{} {
+		return item.(int) * 10
+	}).Get()
+	assert.EqualError(t, err, "bang")
+
+	item, err = SingleFrom(func() (interface{}, error) {
+		return "response", nil
+	}).Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "response", item)
+
+	items, err := SingleOf(1).Observable().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1}, items)
+}
+
+func TestObservableToSingle(t *testing.T) {
+	item, err := Just(1).Single().Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+
+	_, err = Empty().Single().Get()
+	assert.Equal(t, int(rxerrors.NoSuchElementError), err.(rxerrors.BaseError).Code())
+
+	_, err = Just(1, 2).Single().Get()
+	assert.Equal(t, int(rxerrors.ObservableError), err.(rxerrors.BaseError).Code())
+}
+
+func TestSingleSubscribe(t *testing.T) {
+	var got interface{}
+	sub := <-SingleOf("ok").Subscribe(func(item interface{}) {
+		got = item
+	}, nil)
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, "ok", got)
+}
+
+func TestMaybe(t *testing.T) {
+	item, ok, err := MaybeOf(1).Map(func(item interface{}) interface{} {
+		return item.(int) + 1
+	}).Get()
+	assert.Nil(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, 2, item)
+
+	_, ok, err = EmptyMaybe().Get()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = MaybeError(errors.New("bang")).Get()
+	assert.EqualError(t, err, "bang")
+
+	_, ok, err = Empty().Maybe().Get()
+	assert.Nil(t, err)
+	assert.False(t, ok)
+
+	_, _, err = Just(1, 2).Maybe().Get()
+	assert.Error(t, err)
+}
+
+func TestMaybeToSingle(t *testing.T) {
+	item, err := EmptyMaybe().ToSingle("default").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, "default", item)
+
+	item, err = MaybeOf(1).ToSingle("default").Get()
+	assert.Nil(t, err)
+	assert.Equal(t, 1, item)
+
+	_, err = EmptyMaybe().ToSingle().Get()
+	assert.Error(t, err)
+}
+
+func TestMaybeSubscribe(t *testing.T) {
+	empty := false
+	<-EmptyMaybe().Subscribe(nil, nil, func() {
+		empty = true
+	})
+	assert.True(t, empty)
+
+	empty = false
+	<-MaybeOf(1).Subscribe(nil, nil, func() {
+		empty = true
+	})
+	assert.False(t, empty)
+}
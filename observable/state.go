@@ -0,0 +1,92 @@
+package observable
+
+import (
+	"sync"
+
+	"github.com/reactivex/rxgo/fx"
+)
+
+// StateStore keeps per-key state for MapWithState. Implementations may be
+// backed by memory or by external storage, and must be safe for concurrent
+// use if shared between pipelines.
+type StateStore interface {
+	Get(key interface{}) (interface{}, bool)
+	Put(key, state interface{})
+	Delete(key interface{})
+}
+
+// MemoryStateStore is a StateStore held in memory.
+type MemoryStateStore struct {
+	mu     sync.RWMutex
+	states map[interface{}]interface{}
+}
+
+// NewMemoryStateStore creates an empty MemoryStateStore.
+func NewMemoryStateStore() *MemoryStateStore {
+	return &MemoryStateStore{states: make(map[interface{}]interface{})}
+}
+
+// Get returns the state of key, if any.
+func (s *MemoryStateStore) Get(key interface{}) (interface{}, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	state, ok := s.states[key]
+	return state, ok
+}
+
+// Put sets the state of key.
+func (s *MemoryStateStore) Put(key, state interface{}) {
+	s.mu.Lock()
+	s.states[key] = state
+	s.mu.Unlock()
+}
+
+// Delete forgets the state of key.
+func (s *MemoryStateStore) Delete(key interface{}) {
+	s.mu.Lock()
+	delete(s.states, key)
+	s.mu.Unlock()
+}
+
+// Len returns the number of keys with state.
+func (s *MemoryStateStore) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.states)
+}
+
+// MapWithState maps each item in the original Observable with apply, given
+// the state kept for the item's key, which is nil for a new key. The state
+// returned by apply replaces it, and returning a nil state forgets the key.
+// State is kept in an optional StateStore, a fresh MemoryStateStore by
+// default.
+func (o Observable) MapWithState(key fx.KeySelectorFunc, apply fx.StatefulMappableFunc, store ...StateStore) Observable {
+	var states StateStore
+	if len(store) > 0 && store[0] != nil {
+		states = store[0]
+	} else {
+		states = NewMemoryStateStore()
+	}
+
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		for item := range o {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				continue
+			}
+			k := key(item)
+			state, _ := states.Get(k)
+			state, output := apply(state, item)
+			if state == nil {
+				states.Delete(k)
+			} else {
+				states.Put(k, state)
+			}
+			out <- output
+		}
+		closeStage(out)
+	}()
+	return describe(Observable(out), "MapWithState", nil, o)
+}
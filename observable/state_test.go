@@ -0,0 +1,47 @@
+package observable
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryStateStore(t *testing.T) {
+	store := NewMemoryStateStore()
+	_, ok := store.Get("a")
+	assert.False(t, ok)
+
+	store.Put("a", 1)
+	state, ok := store.Get("a")
+	assert.True(t, ok)
+	assert.Equal(t, 1, state)
+	assert.Equal(t, 1, store.Len())
+
+	store.Delete("a")
+	assert.Equal(t, 0, store.Len())
+}
+
+func TestObservableMapWithState(t *testing.T) {
+	store := NewMemoryStateStore()
+	// Number each word by how many times it has been seen, forgetting a
+	// word after its third occurrence.
+	items, err := Just("a", "b", "a", "a", "a").MapWithState(func(item interface{}) interface{} {
+		return item
+	}, func(state, item interface{}) (interface{}, interface{}) {
+		count := 1
+		if state != nil {
+			count = state.(int) + 1
+		}
+		output := item.(string) + string(rune('0'+count))
+		if count == 3 {
+			return nil, output
+		}
+		return count, output
+	}, store).ToSlice()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"a1", "b1", "a2", "a3", "a1"}, items)
+	state, _ := store.Get("a")
+	assert.Equal(t, 1, state)
+	assert.Equal(t, 2, store.Len())
+}
@@ -0,0 +1,170 @@
+package observable
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"io"
+	"os"
+	"time"
+)
+
+// TailOption configures TailFile.
+type TailOption func(*tailConfig)
+
+type tailConfig struct {
+	ctx       context.Context
+	poll      time.Duration
+	fromStart bool
+}
+
+// TailContext stops TailFile, which then completes, once ctx is done.
+// Without it, TailFile follows the file forever.
+func TailContext(ctx context.Context) TailOption {
+	return func(c *tailConfig) {
+		c.ctx = ctx
+	}
+}
+
+// TailPollInterval sets how often TailFile checks the file for new lines,
+// rotation and truncation, 250ms by default.
+func TailPollInterval(d time.Duration) TailOption {
+	return func(c *tailConfig) {
+		c.poll = d
+	}
+}
+
+// TailFromStart makes TailFile emit the lines already in the file before the
+// appended ones.
+func TailFromStart() TailOption {
+	return func(c *tailConfig) {
+		c.fromStart = true
+	}
+}
+
+// TailFile creates an Observable emitting, as strings without their line
+// terminator, the lines appended to the file at path, like tail -F. The file
+// is followed by name: when it is rotated, the rest of the old file is
+// emitted and the new file is then followed from its start, and when it is
+// truncated, it is followed again from its start. A line is only emitted
+// once terminated, except the last line of a rotated file. TailFile emits
+// an error if the file can't be opened initially or read.
+func TailFile(path string, opts ...TailOption) Observable {
+	c := tailConfig{ctx: context.Background(), poll: 250 * time.Millisecond}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	out := make(chan interface{})
+	// Open the file before returning, so that lines appended from now on
+	// are emitted.
+	f, err := os.Open(path)
+	var info os.FileInfo
+	if err == nil {
+		info, err = f.Stat()
+	}
+	if err == nil && !c.fromStart {
+		_, err = f.Seek(0, io.SeekEnd)
+	}
+	if err != nil {
+		if f != nil {
+			f.Close()
+		}
+		go func() {
+			out <- err
+			close(out)
+		}()
+		return created("TailFile", Observable(out))
+	}
+
+	s := register(out)
+	go func() {
+		defer closeStage(out)
+		defer func() { f.Close() }()
+		offset := int64(0)
+		if !c.fromStart {
+			offset = info.Size()
+		}
+		r := bufio.NewReader(f)
+		var partial []byte
+
+		emit := func(line []byte) bool {
+			line = bytes.TrimSuffix(bytes.TrimSuffix(line, []byte("\n")), []byte("\r"))
+			select {
+			case out <- string(line):
+				return true
+			case <-c.ctx.Done():
+				return false
+			case <-s.cancelled():
+				return false
+			}
+		}
+		// readLines emits the complete lines available and keeps the
+		// partial last one for later.
+		readLines := func() bool {
+			for {
+				line, err := r.ReadBytes('\n')
+				offset += int64(len(line))
+				partial = append(partial, line...)
+				if err == io.EOF {
+					return true
+				}
+				if err != nil {
+					out <- err
+					return false
+				}
+				if !emit(partial) {
+					return false
+				}
+				partial = partial[:0]
+			}
+		}
+
+		ticker := time.NewTicker(c.poll)
+		defer ticker.Stop()
+		for {
+			if !readLines() {
+				return
+			}
+			current, err := os.Stat(path)
+			switch {
+			case err != nil:
+				// The file is being rotated: keep reading the old one
+				// until the new one appears.
+			case !os.SameFile(info, current):
+				next, err := os.Open(path)
+				if err != nil {
+					break
+				}
+				if current, err = next.Stat(); err != nil {
+					next.Close()
+					break
+				}
+				if !readLines() || len(partial) > 0 && !emit(partial) {
+					next.Close()
+					return
+				}
+				f.Close()
+				f, info, offset, partial = next, current, 0, partial[:0]
+				r.Reset(f)
+				continue
+			case current.Size() < offset:
+				if _, err := f.Seek(0, io.SeekStart); err != nil {
+					out <- err
+					return
+				}
+				offset, partial = 0, partial[:0]
+				r.Reset(f)
+				continue
+			}
+			select {
+			case <-ticker.C:
+			case <-c.ctx.Done():
+				return
+			case <-s.cancelled():
+				return
+			}
+		}
+	}()
+	return created("TailFile", Observable(out))
+}
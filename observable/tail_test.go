@@ -0,0 +1,94 @@
+package observable
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func appendFile(t *testing.T, path, data string) {
+	f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	if _, err := f.WriteString(data); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func nextLine(t *testing.T, o Observable) interface{} {
+	select {
+	case item := <-o:
+		return item
+	case <-time.After(5 * time.Second):
+		t.Fatal("no line emitted")
+		return nil
+	}
+}
+
+func TestTailFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "old\n")
+	ctx, cancel := context.WithCancel(context.Background())
+	o := TailFile(path, TailContext(ctx), TailPollInterval(time.Millisecond))
+
+	appendFile(t, path, "first\r\nsec")
+	assert.Equal(t, "first", nextLine(t, o))
+	appendFile(t, path, "ond\n")
+	assert.Equal(t, "second", nextLine(t, o))
+
+	cancel()
+	_, ok := <-o
+	assert.False(t, ok)
+}
+
+func TestTailFileRotation(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "app.log")
+	appendFile(t, path, "")
+	o := TailFile(path, TailPollInterval(time.Millisecond))
+
+	appendFile(t, path, "a\n")
+	assert.Equal(t, "a", nextLine(t, o))
+	appendFile(t, path, "b\nunterminated")
+	if err := os.Rename(path, path+".1"); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "c\n")
+	assert.Equal(t, "b", nextLine(t, o))
+	assert.Equal(t, "unterminated", nextLine(t, o))
+	assert.Equal(t, "c", nextLine(t, o))
+}
+
+func TestTailFileTruncation(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "")
+	o := TailFile(path, TailPollInterval(time.Millisecond))
+
+	appendFile(t, path, "a long line\n")
+	assert.Equal(t, "a long line", nextLine(t, o))
+	if err := os.Truncate(path, 0); err != nil {
+		t.Fatal(err)
+	}
+	appendFile(t, path, "b\n")
+	assert.Equal(t, "b", nextLine(t, o))
+}
+
+func TestTailFileFromStart(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "app.log")
+	appendFile(t, path, "a\nb\n")
+	o := TailFile(path, TailFromStart(), TailPollInterval(time.Millisecond))
+	assert.Equal(t, "a", nextLine(t, o))
+	assert.Equal(t, "b", nextLine(t, o))
+}
+
+func TestTailFileMissing(t *testing.T) {
+	items, err := TailFile(filepath.Join(t.TempDir(), "missing.log")).ToSlice()
+	assert.Empty(t, items)
+	assert.True(t, os.IsNotExist(err))
+}
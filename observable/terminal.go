@@ -0,0 +1,287 @@
+package observable
+
+import (
+	"fmt"
+
+	"github.com/reactivex/rxgo"
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/fx"
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observer"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// blockingQueueSize is the default capacity of the queue between an
+// Observable and a BlockingSubscribe caller.
+const blockingQueueSize = 128
+
+// ToChannel returns a channel receiving the items of the original Observable,
+// so that it can be consumed with range, and an accessor for the error which
+// terminated it. The channel is closed when the Observable completes or emits
+// an error; the error is not sent on the channel but reported by the accessor,
+// which returns nil for a stream that completed normally or hasn't terminated.
+func (o Observable) ToChannel(buffer uint) (<-chan interface{}, func() error) {
+	out := make(chan interface{}, int(buffer))
+	var failure error
+	terminated := make(chan struct{})
+
+	go func() {
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				failure = err
+				release(o)
+				break
+			}
+			out <- item
+		}
+		close(terminated)
+		close(out)
+	}()
+
+	return out, func() error {
+		select {
+		case <-terminated:
+			return failure
+		default:
+			return nil
+		}
+	}
+}
+
+// ToSlice blocks until the original Observable terminates and returns its
+// items, along with the error which terminated it, if any. On error, the
+// items received before it are returned.
+func (o Observable) ToSlice() ([]interface{}, error) {
+	items := []interface{}{}
+	for item := range o {
+		if err, isErr := item.(error); isErr {
+			release(o)
+			return items, err
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
+
+// DuplicateKeyPolicy decides how ToMap treats items sharing a key.
+type DuplicateKeyPolicy uint32
+
+const (
+	// DuplicateLastWins keeps the last item for each key.
+	DuplicateLastWins DuplicateKeyPolicy = iota
+
+	// DuplicateError fails with an ObservableError on the first duplicate key.
+	DuplicateError
+
+	// DuplicateCollect maps each key to a []interface{} of all its items.
+	DuplicateCollect
+)
+
+// ToMap blocks until the original Observable terminates and returns its items
+// keyed by the given KeySelectorFunc. Duplicate keys are handled according to
+// an optional DuplicateKeyPolicy, which defaults to DuplicateLastWins.
+func (o Observable) ToMap(key fx.KeySelectorFunc, policy ...DuplicateKeyPolicy) (map[interface{}]interface{}, error) {
+	onDuplicate := DuplicateLastWins
+	if len(policy) > 0 {
+		onDuplicate = policy[0]
+	}
+
+	m := make(map[interface{}]interface{})
+	for item := range o {
+		if err, isErr := item.(error); isErr {
+			release(o)
+			return m, err
+		}
+		k := key(item)
+		switch onDuplicate {
+		case DuplicateError:
+			if _, ok := m[k]; ok {
+				release(o)
+				return m, errors.New(errors.ObservableError, fmt.Sprintf("duplicate key %v", k))
+			}
+			m[k] = item
+		case DuplicateCollect:
+			items, _ := m[k].([]interface{})
+			m[k] = append(items, item)
+		default:
+			m[k] = item
+		}
+	}
+	return m, nil
+}
+
+// ToMultimap blocks until the original Observable terminates and returns, for
+// each key given by the KeySelectorFunc, the values selected from every item
+// with that key, in order.
+func (o Observable) ToMultimap(key fx.KeySelectorFunc, value fx.MappableFunc) (map[interface{}][]interface{}, error) {
+	m := make(map[interface{}][]interface{})
+	for item := range o {
+		if err, isErr := item.(error); isErr {
+			release(o)
+			return m, err
+		}
+		k := key(item)
+		m[k] = append(m[k], value(item))
+	}
+	return m, nil
+}
+
+// BlockingFirst blocks until the original Observable emits its first item and
+// returns it. It fails with a NoSuchElementError if the Observable is empty.
+func (o Observable) BlockingFirst() (interface{}, error) {
+	for item := range o {
+		if err, isErr := item.(error); isErr {
+			release(o)
+			return nil, err
+		}
+		release(o)
+		return item, nil
+	}
+	return nil, errors.New(errors.NoSuchElementError)
+}
+
+// BlockingFirstOrDefault is like BlockingFirst but returns def if the original
+// Observable is empty.
+func (o Observable) BlockingFirstOrDefault(def interface{}) (interface{}, error) {
+	item, err := o.BlockingFirst()
+	if isNoSuchElement(err) {
+		return def, nil
+	}
+	return item, err
+}
+
+// BlockingLast blocks until the original Observable completes and returns its
+// last item. It fails with a NoSuchElementError if the Observable is empty.
+func (o Observable) BlockingLast() (interface{}, error) {
+	var last interface{}
+	found := false
+	for item := range o {
+		if err, isErr := item.(error); isErr {
+			release(o)
+			return nil, err
+		}
+		last, found = item, true
+	}
+	if !found {
+		return nil, errors.New(errors.NoSuchElementError)
+	}
+	return last, nil
+}
+
+// BlockingLastOrDefault is like BlockingLast but returns def if the original
+// Observable is empty.
+func (o Observable) BlockingLastOrDefault(def interface{}) (interface{}, error) {
+	item, err := o.BlockingLast()
+	if isNoSuchElement(err) {
+		return def, nil
+	}
+	return item, err
+}
+
+// BlockingSingle blocks until the original Observable completes and returns
+// its only item. It fails with a NoSuchElementError if the Observable is empty,
+// and with an ObservableError as soon as it emits a second item.
+func (o Observable) BlockingSingle() (interface{}, error) {
+	var single interface{}
+	found := false
+	for item := range o {
+		if err, isErr := item.(error); isErr {
+			release(o)
+			return nil, err
+		}
+		if found {
+			release(o)
+			return nil, errors.New(errors.ObservableError, "observable emits more than one item")
+		}
+		single, found = item, true
+	}
+	if !found {
+		return nil, errors.New(errors.NoSuchElementError)
+	}
+	return single, nil
+}
+
+// BlockingSingleOrDefault is like BlockingSingle but returns def if the
+// original Observable is empty.
+func (o Observable) BlockingSingleOrDefault(def interface{}) (interface{}, error) {
+	item, err := o.BlockingSingle()
+	if isNoSuchElement(err) {
+		return def, nil
+	}
+	return item, err
+}
+
+func isNoSuchElement(err error) bool {
+	baseErr, ok := err.(errors.BaseError)
+	return ok && baseErr.Code() == int(errors.NoSuchElementError)
+}
+
+// ForEach subscribes the given handlers to the original Observable, blocks
+// until it terminates and returns the error which terminated it, if any. Any
+// of the handlers may be nil.
+func (o Observable) ForEach(onNext handlers.NextFunc, onError handlers.ErrFunc, onDone handlers.DoneFunc) error {
+	sub := <-o.Subscribe(observer.Observer{
+		NextHandler: onNext,
+		ErrHandler:  onError,
+		DoneHandler: onDone,
+	})
+	return sub.Err()
+}
+
+// Iterator drains an Observable incrementally with pull semantics.
+type Iterator struct {
+	items <-chan interface{}
+	err   func() error
+}
+
+// Iterator returns an Iterator over the original Observable, which buffers
+// up to capacity items ahead of the consumer.
+func (o Observable) Iterator(capacity uint) *Iterator {
+	items, err := o.ToChannel(capacity)
+	return &Iterator{items: items, err: err}
+}
+
+// Next blocks until the next item is available and returns it. It returns
+// false once the Observable has terminated, after which Err reports the
+// error which terminated it, if any.
+func (it *Iterator) Next() (interface{}, bool) {
+	item, ok := <-it.items
+	return item, ok
+}
+
+// Err returns the error which terminated the Observable, or nil if it
+// completed normally or is still running.
+func (it *Iterator) Err() error {
+	return it.err()
+}
+
+// BlockingSubscribe subscribes an EventHandler and runs it on the calling
+// goroutine, returning once the original Observable has terminated. Items
+// are handed over from the source through a bounded queue of an optional
+// capacity, which defaults to 128.
+func (o Observable) BlockingSubscribe(handler rx.EventHandler, capacity ...uint) subscription.Subscription {
+	size := uint(blockingQueueSize)
+	if len(capacity) > 0 {
+		size = capacity[0]
+	}
+
+	sub := subscription.New().Subscribe()
+	ob := CheckEventHandler(handler)
+
+	items, errf := o.ToChannel(size)
+	for item := range items {
+		if batch, isBatch := item.(Batch); isBatch {
+			ob.OnNextBatch(batch)
+			continue
+		}
+		ob.OnNext(item)
+	}
+
+	if err := errf(); err != nil {
+		ob.OnError(err)
+		sub.Error = err
+	} else {
+		ob.OnDone()
+	}
+	return sub.Unsubscribe()
+}
@@ -0,0 +1,217 @@
+package observable
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observer"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestObservableToChannel(t *testing.T) {
+	ch, errf := Range(0, 3).ToChannel(1)
+
+	nums := []int{}
+	for item := range ch {
+		nums = append(nums, item.(int))
+	}
+
+	assert.Exactly(t, []int{0, 1, 2}, nums)
+	assert.Nil(t, errf())
+}
+
+func TestObservableToChannelWithError(t *testing.T) {
+	ch, errf := Just(1, errors.New("bang"), 2).ToChannel(0)
+
+	items := []interface{}{}
+	for item := range ch {
+		items = append(items, item)
+	}
+
+	assert.Exactly(t, []interface{}{1}, items)
+	assert.EqualError(t, errf(), "bang")
+}
+
+func TestObservableToSlice(t *testing.T) {
+	items, err := Range(0, 3).ToSlice()
+
+	assert.Exactly(t, []interface{}{0, 1, 2}, items)
+	assert.Nil(t, err)
+}
+
+func TestObservableToSliceWithEmpty(t *testing.T) {
+	items, err := Empty().ToSlice()
+
+	assert.Exactly(t, []interface{}{}, items)
+	assert.Nil(t, err)
+}
+
+func TestObservableToSliceWithError(t *testing.T) {
+	items, err := Just(1, errors.New("bang"), 2).ToSlice()
+
+	assert.Exactly(t, []interface{}{1}, items)
+	assert.EqualError(t, err, "bang")
+}
+
+func parity(item interface{}) interface{} {
+	return item.(int) % 2
+}
+
+func TestObservableToMap(t *testing.T) {
+	m, err := Range(0, 5).ToMap(parity)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{0: 4, 1: 3}, m)
+}
+
+func TestObservableToMapWithDuplicateError(t *testing.T) {
+	_, err := Range(0, 5).ToMap(parity, DuplicateError)
+	assert.EqualError(t, err, "3 - duplicate key 0")
+
+	m, err := Range(0, 2).ToMap(parity, DuplicateError)
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{0: 0, 1: 1}, m)
+}
+
+func TestObservableToMapWithDuplicateCollect(t *testing.T) {
+	m, err := Range(0, 5).ToMap(parity, DuplicateCollect)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}]interface{}{
+		0: []interface{}{0, 2, 4},
+		1: []interface{}{1, 3},
+	}, m)
+}
+
+func TestObservableToMultimap(t *testing.T) {
+	square := func(item interface{}) interface{} {
+		return item.(int) * item.(int)
+	}
+	m, err := Range(0, 5).ToMultimap(parity, square)
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[interface{}][]interface{}{
+		0: {0, 4, 16},
+		1: {1, 9},
+	}, m)
+}
+
+func TestObservableBlockingFirst(t *testing.T) {
+	item, err := Range(3, 6).BlockingFirst()
+	assert.Equal(t, 3, item)
+	assert.Nil(t, err)
+
+	_, err = Empty().BlockingFirst()
+	assert.True(t, isNoSuchElement(err))
+
+	item, err = Empty().BlockingFirstOrDefault(7)
+	assert.Equal(t, 7, item)
+	assert.Nil(t, err)
+
+	_, err = Throw(errors.New("bang")).BlockingFirstOrDefault(7)
+	assert.EqualError(t, err, "bang")
+}
+
+func TestObservableBlockingLast(t *testing.T) {
+	item, err := Range(3, 6).BlockingLast()
+	assert.Equal(t, 5, item)
+	assert.Nil(t, err)
+
+	_, err = Empty().BlockingLast()
+	assert.True(t, isNoSuchElement(err))
+
+	item, err = Empty().BlockingLastOrDefault(7)
+	assert.Equal(t, 7, item)
+	assert.Nil(t, err)
+}
+
+func TestObservableBlockingSingle(t *testing.T) {
+	item, err := Just(3).BlockingSingle()
+	assert.Equal(t, 3, item)
+	assert.Nil(t, err)
+
+	_, err = Just(3, 4).BlockingSingle()
+	assert.Error(t, err)
+	assert.False(t, isNoSuchElement(err))
+
+	_, err = Empty().BlockingSingle()
+	assert.True(t, isNoSuchElement(err))
+
+	item, err = Empty().BlockingSingleOrDefault(7)
+	assert.Equal(t, 7, item)
+	assert.Nil(t, err)
+}
+
+func TestObservableForEach(t *testing.T) {
+	nums := []int{}
+	done := false
+
+	err := Range(0, 3).ForEach(func(item interface{}) {
+		nums = append(nums, item.(int))
+	}, nil, func() {
+		done = true
+	})
+
+	assert.Nil(t, err)
+	assert.Exactly(t, []int{0, 1, 2}, nums)
+	assert.True(t, done)
+}
+
+func TestObservableForEachWithError(t *testing.T) {
+	var handled error
+
+	err := Just(1, errors.New("bang")).ForEach(nil, func(err error) {
+		handled = err
+	}, nil)
+
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, err, handled)
+}
+
+func TestObservableIterator(t *testing.T) {
+	it := Just(1, 2, errors.New("bang")).Iterator(2)
+
+	item, ok := it.Next()
+	assert.Equal(t, 1, item)
+	assert.True(t, ok)
+
+	item, ok = it.Next()
+	assert.Equal(t, 2, item)
+	assert.True(t, ok)
+
+	_, ok = it.Next()
+	assert.False(t, ok)
+	assert.EqualError(t, it.Err(), "bang")
+}
+
+func TestObservableBlockingSubscribe(t *testing.T) {
+	nums := []int{}
+	done := false
+	onNext := handlers.NextFunc(func(item interface{}) {
+		nums = append(nums, item.(int))
+	})
+	onDone := handlers.DoneFunc(func() {
+		done = true
+	})
+
+	sub := Range(0, 5).BlockingSubscribe(observer.New(onNext, onDone), 2)
+
+	assert.Exactly(t, []int{0, 1, 2, 3, 4}, nums)
+	assert.True(t, done)
+	assert.Nil(t, sub.Err())
+	assert.False(t, sub.UnsubscribeAt.Before(sub.SubscribeAt))
+}
+
+func TestObservableBlockingSubscribeWithError(t *testing.T) {
+	var handled error
+	onError := handlers.ErrFunc(func(err error) {
+		handled = err
+	})
+
+	sub := Just(1, errors.New("bang")).BlockingSubscribe(onError)
+
+	assert.EqualError(t, handled, "bang")
+	assert.EqualError(t, sub.Err(), "bang")
+}
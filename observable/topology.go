@@ -0,0 +1,128 @@
+package observable
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"sync/atomic"
+)
+
+// TopologyNode is a source or an operator of a pipeline.
+type TopologyNode struct {
+	ID     int                    `json:"id"`
+	Name   string                 `json:"name"`
+	Config map[string]interface{} `json:"config,omitempty"`
+	Inputs []int                  `json:"inputs,omitempty"`
+}
+
+// Topology is the graph of the sources and operators an Observable is built
+// from, with sources first and the Observable itself last. It marshals to
+// JSON as is.
+type Topology struct {
+	Nodes []TopologyNode `json:"nodes"`
+}
+
+// DOT renders the Topology in the Graphviz DOT language.
+func (t Topology) DOT() string {
+	var b strings.Builder
+	b.WriteString("digraph pipeline {\n")
+	for _, n := range t.Nodes {
+		label := n.Name
+		if len(n.Config) > 0 {
+			keys := make([]string, 0, len(n.Config))
+			for k := range n.Config {
+				keys = append(keys, k)
+			}
+			sort.Strings(keys)
+			for _, k := range keys {
+				label += fmt.Sprintf("\n%s=%v", k, n.Config[k])
+			}
+		}
+		// %q escapes the line breaks between the name and the
+		// configuration the way DOT expects.
+		fmt.Fprintf(&b, "\tn%d [label=%q];\n", n.ID, label)
+	}
+	for _, n := range t.Nodes {
+		for _, in := range n.Inputs {
+			fmt.Fprintf(&b, "\tn%d -> n%d;\n", in, n.ID)
+		}
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+type topologyEntry struct {
+	name   string
+	config map[string]interface{}
+	inputs []Observable
+}
+
+var (
+	topologyEnabled  int32
+	topologyRegistry sync.Map
+)
+
+// EnableTopology switches the recording of pipelines on or off. It is off
+// by default, as every recorded Observable is retained until ResetTopology.
+func EnableTopology(enabled bool) {
+	var v int32
+	if enabled {
+		v = 1
+	}
+	atomic.StoreInt32(&topologyEnabled, v)
+}
+
+// ResetTopology forgets every recorded Observable.
+func ResetTopology() {
+	topologyRegistry.Range(func(key, _ interface{}) bool {
+		topologyRegistry.Delete(key)
+		return true
+	})
+}
+
+// describe records that o is built by the source or operator called name
+// from inputs, when recording is enabled, and returns o.
+func describe(o Observable, name string, config map[string]interface{}, inputs ...Observable) Observable {
+	if atomic.LoadInt32(&topologyEnabled) == 1 {
+		topologyRegistry.Store(o, topologyEntry{name: name, config: config, inputs: inputs})
+	}
+	return o
+}
+
+// Describe wraps op so that, when recording is enabled, the Observable it
+// returns appears in topologies as a node called name with the given
+// configuration.
+func Describe(name string, op Operator, config map[string]interface{}) Operator {
+	return func(o Observable) Observable {
+		return describe(op(o), name, config, o)
+	}
+}
+
+// Topology returns the graph of the sources and operators the Observable is
+// built from, as recorded while EnableTopology is on. Observables which
+// weren't recorded appear as nodes called "Observable" without inputs.
+func (o Observable) Topology() Topology {
+	var t Topology
+	ids := make(map[Observable]int)
+	var visit func(o Observable) int
+	visit = func(o Observable) int {
+		if id, ok := ids[o]; ok {
+			return id
+		}
+		node := TopologyNode{Name: "Observable"}
+		if v, ok := topologyRegistry.Load(o); ok {
+			entry := v.(topologyEntry)
+			node.Name, node.Config = entry.name, entry.config
+			for _, in := range entry.inputs {
+				node.Inputs = append(node.Inputs, visit(in))
+			}
+		}
+		node.ID = len(t.Nodes)
+		ids[o] = node.ID
+		t.Nodes = append(t.Nodes, node)
+		return node.ID
+	}
+	visit(o)
+	return t
+}
@@ -0,0 +1,99 @@
+package observable
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTopology(t *testing.T) {
+	EnableTopology(true)
+	defer func() {
+		EnableTopology(false)
+		ResetTopology()
+	}()
+
+	double := Describe("Double", func(o Observable) Observable {
+		return o.Map(func(item interface{}) interface{} { return item.(int) * 2 })
+	}, map[string]interface{}{"factor": 2})
+	o := Range(0, 10).
+		Filter(func(item interface{}) bool { return item.(int)%2 == 0 }).
+		Pipe(double).
+		Take(3)
+
+	topology := o.Topology()
+	assert.Equal(t, []TopologyNode{
+		{ID: 0, Name: "Range"},
+		{ID: 1, Name: "Filter", Inputs: []int{0}},
+		{ID: 2, Name: "Double", Config: map[string]interface{}{"factor": 2}, Inputs: []int{1}},
+		{ID: 3, Name: "Take", Config: map[string]interface{}{"n": uint(3)}, Inputs: []int{2}},
+	}, topology.Nodes)
+
+	data, err := json.Marshal(topology)
+	assert.Nil(t, err)
+	assert.Contains(t, string(data), `{"id":2,"name":"Double","config":{"factor":2},"inputs":[1]}`)
+
+	assert.Equal(t, `digraph pipeline {
+	n0 [label="Range"];
+	n1 [label="Filter"];
+	n2 [label="Double\nfactor=2"];
+	n3 [label="Take\nn=3"];
+	n0 -> n1;
+	n1 -> n2;
+	n2 -> n3;
+}
+`, topology.DOT())
+
+	items, _ := o.ToSlice()
+	assert.Equal(t, []interface{}{0, 4, 8}, items)
+}
+
+func TestTopologyOperators(t *testing.T) {
+	EnableTopology(true)
+	defer func() {
+		EnableTopology(false)
+		ResetTopology()
+	}()
+
+	identity := func(item interface{}) interface{} { return item }
+	stop := Never()
+	o := Just(1, 2, 2, 3).
+		Distinct(identity).
+		TakeUntil(stop).
+		Pairwise().
+		TakeLast(2).
+		Count()
+
+	assert.Equal(t, []TopologyNode{
+		{ID: 0, Name: "Just"},
+		{ID: 1, Name: "Distinct", Inputs: []int{0}},
+		{ID: 2, Name: "Never"},
+		{ID: 3, Name: "TakeUntil", Inputs: []int{1, 2}},
+		{ID: 4, Name: "Pairwise", Inputs: []int{3}},
+		{ID: 5, Name: "TakeLast", Config: map[string]interface{}{"n": uint(2)}, Inputs: []int{4}},
+		{ID: 6, Name: "Count", Inputs: []int{5}},
+	}, o.Topology().Nodes)
+}
+
+func TestTopologyGroupBy(t *testing.T) {
+	EnableTopology(true)
+	defer func() {
+		EnableTopology(false)
+		ResetTopology()
+	}()
+
+	identity := func(item interface{}) interface{} { return item }
+	assert.Equal(t, []TopologyNode{
+		{ID: 0, Name: "Just"},
+		{ID: 1, Name: "GroupBy", Inputs: []int{0}},
+	}, Just(1).GroupBy(identity).Topology().Nodes)
+	assert.Equal(t, []TopologyNode{
+		{ID: 0, Name: "Just"},
+		{ID: 1, Name: "GroupByEvicting", Inputs: []int{0}},
+	}, Just(1).GroupByEvicting(identity, GroupEviction{MaxGroups: 1}).Topology().Nodes)
+}
+
+func TestTopologyDisabled(t *testing.T) {
+	assert.Equal(t, []TopologyNode{{Name: "Observable"}}, Just(1).Map(increment).Topology().Nodes)
+}
@@ -0,0 +1,327 @@
+package observable
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/fx"
+)
+
+// Window describes how WindowAggregate assigns items to windows. Create one
+// with TumblingWindow, HoppingWindow or SessionWindow.
+type Window struct {
+	size time.Duration
+	hop  time.Duration
+	gap  time.Duration
+}
+
+// TumblingWindow returns fixed-size, non-overlapping windows.
+func TumblingWindow(size time.Duration) Window {
+	return Window{size: size, hop: size}
+}
+
+// HoppingWindow returns fixed-size windows starting every hop, so that an
+// item belongs to several windows when hop is smaller than size.
+func HoppingWindow(size, hop time.Duration) Window {
+	return Window{size: size, hop: hop}
+}
+
+// SessionWindow returns windows holding bursts of items separated by less
+// than gap. A session closes once gap passes without a new item.
+func SessionWindow(gap time.Duration) Window {
+	return Window{gap: gap}
+}
+
+// validate returns an error if the window has no positive size and hop, or
+// gap.
+func (w Window) validate() error {
+	if w.gap > 0 || w.size > 0 && w.hop > 0 {
+		return nil
+	}
+	return errors.New(errors.ObservableError, fmt.Sprintf("invalid window: size %v, hop %v, gap %v", w.size, w.hop, w.gap))
+}
+
+// WindowResult is the aggregate of the items of a closed window.
+type WindowResult struct {
+	Start time.Time
+	End   time.Time
+	Value interface{}
+	// Late reports whether the result updates an already emitted window
+	// following a late item.
+	Late bool
+}
+
+type timedItem struct {
+	at   time.Time
+	item interface{}
+}
+
+type pane struct {
+	start time.Time
+	end   time.Time
+	acc   interface{}
+	items []timedItem // session windows only, folded when the pane closes
+}
+
+// windower assigns timestamped items to panes and folds them, independently
+// of whether time is driven by the clock or by the items themselves.
+type windower struct {
+	window  Window
+	initial fx.EmittableFunc
+	fold    fx.ScannableFunc
+	origin  *time.Time
+	panes   []*pane // open panes, ordered by end
+
+	// retain keeps closed panes in retained so that late items can still
+	// update them.
+	retain   bool
+	retained []*pane
+}
+
+func (w *windower) add(at time.Time, item interface{}) {
+	w.panes, _ = w.assign(w.panes, at, item, time.Time{})
+}
+
+// lastStart returns the start of the latest fixed-size window holding at.
+func (w *windower) lastStart(at time.Time) time.Time {
+	origin := time.Unix(0, 0)
+	if w.origin != nil {
+		origin = *w.origin
+	}
+	hop := w.window.hop
+	k := at.Sub(origin) / hop
+	if at.Before(origin.Add(k * hop)) {
+		k--
+	}
+	return origin.Add(k * hop)
+}
+
+// assign folds item into the panes of panes it belongs to, creating them as
+// needed but skipping those which would end at or before floor. It returns
+// the updated panes and those the item was folded into.
+func (w *windower) assign(panes []*pane, at time.Time, item interface{}, floor time.Time) ([]*pane, []*pane) {
+	if w.window.gap > 0 {
+		return w.assignSession(panes, at, item, floor)
+	}
+	var touched []*pane
+	for start := w.lastStart(at); start.Add(w.window.size).After(at); start = start.Add(-w.window.hop) {
+		end := start.Add(w.window.size)
+		if !end.After(floor) {
+			break
+		}
+		var p *pane
+		for _, candidate := range panes {
+			if candidate.start.Equal(start) {
+				p = candidate
+				break
+			}
+		}
+		if p == nil {
+			p = &pane{start: start, end: end, acc: w.initial()}
+			panes = append(panes, p)
+		}
+		p.acc = w.fold(p.acc, item)
+		touched = append(touched, p)
+	}
+	sortPanes(panes)
+	return panes, touched
+}
+
+func (w *windower) assignSession(panes []*pane, at time.Time, item interface{}, floor time.Time) ([]*pane, []*pane) {
+	merged := &pane{start: at, end: at.Add(w.window.gap), items: []timedItem{{at, item}}}
+	var rest []*pane
+	for _, p := range panes {
+		if !w.overlaps(p, at) {
+			rest = append(rest, p)
+			continue
+		}
+		if p.start.Before(merged.start) {
+			merged.start = p.start
+		}
+		if p.end.After(merged.end) {
+			merged.end = p.end
+		}
+		merged.items = append(merged.items, p.items...)
+	}
+	if !merged.end.After(floor) {
+		return panes, nil
+	}
+	panes = append(rest, merged)
+	sortPanes(panes)
+	return panes, []*pane{merged}
+}
+
+// overlaps reports whether an item at would join the session p.
+func (w *windower) overlaps(p *pane, at time.Time) bool {
+	return p.start.Before(at.Add(w.window.gap)) && p.end.After(at)
+}
+
+func sortPanes(panes []*pane) {
+	sort.SliceStable(panes, func(i, j int) bool {
+		return panes[i].end.Before(panes[j].end)
+	})
+}
+
+// late reports whether every window an item at belongs to would already be
+// closed by watermark.
+func (w *windower) late(at, watermark time.Time) bool {
+	if w.window.gap > 0 {
+		for _, p := range w.panes {
+			if w.overlaps(p, at) {
+				return false
+			}
+		}
+		return !at.Add(w.window.gap).After(watermark)
+	}
+	return !w.lastStart(at).Add(w.window.size).After(watermark)
+}
+
+// update folds a late item into the retained panes ending after floor,
+// returning the updated results.
+func (w *windower) update(at time.Time, item interface{}, floor time.Time) []WindowResult {
+	var touched []*pane
+	w.retained, touched = w.assign(w.retained, at, item, floor)
+	results := make([]WindowResult, len(touched))
+	for i, p := range touched {
+		results[i] = w.result(p)
+		results[i].Late = true
+	}
+	return results
+}
+
+// expire forgets retained panes ending at or before t.
+func (w *windower) expire(t time.Time) {
+	n := sort.Search(len(w.retained), func(i int) bool {
+		return w.retained[i].end.After(t)
+	})
+	w.retained = w.retained[n:]
+}
+
+// nextEnd returns the end of the earliest open pane.
+func (w *windower) nextEnd() (time.Time, bool) {
+	if len(w.panes) == 0 {
+		return time.Time{}, false
+	}
+	return w.panes[0].end, true
+}
+
+// closeUntil closes every pane ending at or before t, returning their
+// results in order.
+func (w *windower) closeUntil(t time.Time) []WindowResult {
+	n := sort.Search(len(w.panes), func(i int) bool {
+		return w.panes[i].end.After(t)
+	})
+	results := make([]WindowResult, n)
+	for i, p := range w.panes[:n] {
+		results[i] = w.result(p)
+	}
+	if w.retain {
+		w.retained = append(w.retained, w.panes[:n]...)
+		sortPanes(w.retained)
+	}
+	w.panes = w.panes[n:]
+	return results
+}
+
+func (w *windower) flush() []WindowResult {
+	results := make([]WindowResult, len(w.panes))
+	for i, p := range w.panes {
+		results[i] = w.result(p)
+	}
+	w.panes = nil
+	return results
+}
+
+func (w *windower) result(p *pane) WindowResult {
+	acc := p.acc
+	if p.items != nil {
+		sort.SliceStable(p.items, func(i, j int) bool {
+			return p.items[i].at.Before(p.items[j].at)
+		})
+		acc = w.initial()
+		for _, ti := range p.items {
+			acc = w.fold(acc, ti.item)
+		}
+	}
+	return WindowResult{Start: p.start, End: p.end, Value: acc}
+}
+
+// WindowAggregate assigns the items of the original Observable to windows by
+// their arrival time and emits a WindowResult folding each window's items
+// with fold, starting from initial, as soon as the window closes. Windows
+// are aligned on the first item, only windows holding at least one item are
+// emitted, and windows still open when the original Observable completes are
+// emitted straight away. A window without a positive size and hop, or gap,
+// makes it emit an error. Arrival times are read from an optional Clock,
+// which defaults to SystemClock; a window also closes when an item arrives
+// after its end, so that a ManualClock drives the windows deterministically.
+func (o Observable) WindowAggregate(window Window, initial fx.EmittableFunc, fold fx.ScannableFunc, clock ...Clock) Observable {
+	c := clockOrDefault(clock)
+	out := make(chan interface{})
+	register(out, o)
+	go func() {
+		defer closeStage(out)
+		if err := window.validate(); err != nil {
+			out <- err
+			return
+		}
+		w := &windower{window: window, initial: initial, fold: fold}
+		emit := func(results []WindowResult) {
+			for _, result := range results {
+				out <- result
+			}
+		}
+
+		var timer *time.Timer
+		var fire <-chan time.Time
+		var armed time.Time
+		rearm := func() {
+			end, ok := w.nextEnd()
+			if ok && end.Equal(armed) && fire != nil {
+				return
+			}
+			if timer != nil {
+				timer.Stop()
+			}
+			fire = nil
+			if ok {
+				timer = time.NewTimer(end.Sub(c.Now()))
+				fire = timer.C
+				armed = end
+			}
+		}
+		defer func() {
+			if timer != nil {
+				timer.Stop()
+			}
+		}()
+
+		for {
+			select {
+			case item, ok := <-o:
+				if !ok {
+					emit(w.flush())
+					return
+				}
+				if _, isErr := item.(error); isErr {
+					out <- item
+					return
+				}
+				now := c.Now()
+				if w.origin == nil {
+					w.origin = &now
+				}
+				emit(w.closeUntil(now))
+				w.add(now, item)
+				rearm()
+			case <-fire:
+				fire = nil
+				emit(w.closeUntil(c.Now()))
+				rearm()
+			}
+		}
+	}()
+	return describe(Observable(out), "WindowAggregate", nil, o)
+}
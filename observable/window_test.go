@@ -0,0 +1,134 @@
+package observable
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func sumFold(acc, item interface{}) interface{} {
+	return acc.(int) + item.(int)
+}
+
+func zero() interface{} {
+	return 0
+}
+
+func values(results []WindowResult) []interface{} {
+	vs := make([]interface{}, len(results))
+	for i, r := range results {
+		vs[i] = r.Value
+	}
+	return vs
+}
+
+func TestWindowerTumbling(t *testing.T) {
+	base := time.Unix(0, 0)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	w := &windower{window: TumblingWindow(10 * time.Millisecond), initial: zero, fold: sumFold}
+
+	w.add(at(1), 1)
+	w.add(at(9), 2)
+	w.add(at(10), 3)
+	w.add(at(25), 4)
+
+	results := w.closeUntil(at(20))
+	assert.Equal(t, []interface{}{3, 3}, values(results))
+	assert.Equal(t, at(0), results[0].Start)
+	assert.Equal(t, at(10), results[0].End)
+	assert.Equal(t, []interface{}{4}, values(w.flush()))
+}
+
+func TestWindowerHopping(t *testing.T) {
+	base := time.Unix(0, 0)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	w := &windower{window: HoppingWindow(10*time.Millisecond, 5*time.Millisecond), initial: zero, fold: sumFold}
+
+	w.add(at(2), 1)
+	w.add(at(7), 2)
+	w.add(at(12), 4)
+
+	// Windows [-5,5) [0,10) [5,15) [10,20)
+	assert.Equal(t, []interface{}{1, 3, 6, 4}, values(w.flush()))
+}
+
+func TestWindowerSession(t *testing.T) {
+	base := time.Unix(0, 0)
+	at := func(ms int) time.Time { return base.Add(time.Duration(ms) * time.Millisecond) }
+	w := &windower{window: SessionWindow(5 * time.Millisecond), initial: zero, fold: sumFold}
+
+	w.add(at(0), 1)
+	w.add(at(3), 2)
+	w.add(at(20), 4)
+	w.add(at(12), 8)
+	w.add(at(16), 16)
+
+	results := w.flush()
+	assert.Equal(t, []interface{}{3, 28}, values(results))
+	assert.Equal(t, at(0), results[0].Start)
+	assert.Equal(t, at(8), results[0].End)
+	assert.Equal(t, at(12), results[1].Start)
+	assert.Equal(t, at(25), results[1].End)
+}
+
+func TestObservableWindowAggregate(t *testing.T) {
+	in := make(chan interface{})
+	results := FromChannel(in).WindowAggregate(TumblingWindow(50*time.Millisecond), zero, sumFold)
+
+	in <- 1
+	in <- 2
+	select {
+	case result := <-results:
+		assert.Equal(t, 3, result.(WindowResult).Value)
+	case <-time.After(time.Second):
+		assert.Fail(t, "window did not close")
+	}
+
+	in <- 4
+	close(in)
+	result := <-results
+	assert.Equal(t, 4, result.(WindowResult).Value)
+	_, ok := <-results
+	assert.False(t, ok)
+}
+
+func TestObservableWindowAggregateClock(t *testing.T) {
+	start := time.Unix(100, 0)
+	clock := NewManualClock(start)
+	in := make(chan interface{})
+	results := FromChannel(in).WindowAggregate(TumblingWindow(time.Hour), zero, sumFold, clock)
+
+	in <- 1
+	in <- 2
+	<-time.After(10 * time.Millisecond)
+	clock.Advance(time.Hour)
+	in <- 4
+	assert.Equal(t, WindowResult{Start: start, End: start.Add(time.Hour), Value: 3}, <-results)
+	close(in)
+	assert.Equal(t, WindowResult{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour), Value: 4}, <-results)
+	_, ok := <-results
+	assert.False(t, ok)
+}
+
+func TestObservableSessionWindowAggregate(t *testing.T) {
+	in := make(chan interface{})
+	results := FromChannel(in).WindowAggregate(SessionWindow(30*time.Millisecond), zero, sumFold)
+
+	in <- 1
+	in <- 2
+	result := <-results
+	assert.Equal(t, 3, result.(WindowResult).Value)
+	close(in)
+	_, ok := <-results
+	assert.False(t, ok)
+}
+
+func TestWindowAggregateInvalidWindow(t *testing.T) {
+	for _, window := range []Window{{}, TumblingWindow(0), HoppingWindow(time.Second, 0), SessionWindow(-time.Second)} {
+		_, err := Just(1, 2).WindowAggregate(window, zero, sumFold).ToSlice()
+		assert.NotNil(t, err)
+		_, err = Just(1, 2).EventTimeWindowAggregate(window, func(interface{}) time.Time { return time.Now() }, 0, zero, sumFold).ToSlice()
+		assert.NotNil(t, err)
+	}
+}
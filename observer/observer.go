@@ -10,6 +10,10 @@ type Observer struct {
 	NextHandler handlers.NextFunc
 	ErrHandler  handlers.ErrFunc
 	DoneHandler handlers.DoneFunc
+
+	// BatchHandler, if set, receives batches emitted by a batched Observable
+	// in a single call. Otherwise NextHandler is called once per item.
+	BatchHandler handlers.BatchFunc
 }
 
 // DefaultObserver guarantees any handler won't be nil.
@@ -19,14 +23,14 @@ var DefaultObserver = Observer{
 	DoneHandler: func() {},
 }
 
-// Handle registers Observer to EventHandler.
+// Handle registers Observer to EventHandler. Nil handlers are skipped, so
+// the zero Observer is safe to use.
 func (ob Observer) Handle(item interface{}) {
 	switch item := item.(type) {
 	case error:
-		ob.ErrHandler(item)
-		return
+		ob.OnError(item)
 	default:
-		ob.NextHandler(item)
+		ob.OnNext(item)
 	}
 }
 
@@ -43,6 +47,8 @@ func New(eventHandlers ...rx.EventHandler) Observer {
 				ob.ErrHandler = handler
 			case handlers.DoneFunc:
 				ob.DoneHandler = handler
+			case handlers.BatchFunc:
+				ob.BatchHandler = handler
 			case Observer:
 				ob = handler
 			}
@@ -51,6 +57,47 @@ func New(eventHandlers ...rx.EventHandler) Observer {
 	return ob
 }
 
+// Option configures an Observer built by NewWith.
+type Option func(*Observer)
+
+// WithOnNext sets the NextHandler of an Observer.
+func WithOnNext(handler handlers.NextFunc) Option {
+	return func(ob *Observer) {
+		ob.NextHandler = handler
+	}
+}
+
+// WithOnError sets the ErrHandler of an Observer.
+func WithOnError(handler handlers.ErrFunc) Option {
+	return func(ob *Observer) {
+		ob.ErrHandler = handler
+	}
+}
+
+// WithOnDone sets the DoneHandler of an Observer.
+func WithOnDone(handler handlers.DoneFunc) Option {
+	return func(ob *Observer) {
+		ob.DoneHandler = handler
+	}
+}
+
+// WithOnBatch sets the BatchHandler of an Observer.
+func WithOnBatch(handler handlers.BatchFunc) Option {
+	return func(ob *Observer) {
+		ob.BatchHandler = handler
+	}
+}
+
+// NewWith constructs a new Observer from the default Observer and any number
+// of Options. Handlers which are not set, or set to nil, do nothing.
+func NewWith(opts ...Option) Observer {
+	ob := DefaultObserver
+	for _, opt := range opts {
+		opt(&ob)
+	}
+	return ob
+}
+
 // OnNext applies Observer's NextHandler to an Item
 func (ob Observer) OnNext(item interface{}) {
 	switch item := item.(type) {
@@ -63,6 +110,18 @@ func (ob Observer) OnNext(item interface{}) {
 	}
 }
 
+// OnNextBatch applies Observer's BatchHandler to a batch of items, or its
+// NextHandler to each item when there is no BatchHandler.
+func (ob Observer) OnNextBatch(items []interface{}) {
+	if ob.BatchHandler != nil {
+		ob.BatchHandler(items)
+		return
+	}
+	for _, item := range items {
+		ob.OnNext(item)
+	}
+}
+
 // OnError applies Observer's ErrHandler to an error
 func (ob Observer) OnError(err error) {
 	if ob.ErrHandler != nil {
@@ -1,6 +1,7 @@
 package observer
 
 import (
+	"errors"
 	"testing"
 
 	"github.com/reactivex/rxgo/handlers"
@@ -48,3 +49,65 @@ func TestCreateNewObserverWithObserver(t *testing.T) {
 	assert.Equal(t, "Next", nexttext)
 	assert.Equal(t, "Hello", donetext)
 }
+
+func TestObserverOnNextBatch(t *testing.T) {
+	nums := []interface{}{}
+	batches := 0
+
+	nextf := handlers.NextFunc(func(item interface{}) {
+		nums = append(nums, item)
+	})
+	batchf := handlers.BatchFunc(func(items []interface{}) {
+		batches++
+		nums = append(nums, items...)
+	})
+
+	New(nextf).OnNextBatch([]interface{}{1, 2})
+	assert.Equal(t, 0, batches)
+
+	New(nextf, batchf).OnNextBatch([]interface{}{3, 4})
+	assert.Equal(t, 1, batches)
+
+	assert.Exactly(t, []interface{}{1, 2, 3, 4}, nums)
+}
+
+func TestCreateNewObserverWithOptions(t *testing.T) {
+	var items []interface{}
+	var failure error
+	done := false
+
+	ob := NewWith(
+		WithOnNext(func(item interface{}) {
+			items = append(items, item)
+		}),
+		WithOnError(func(err error) {
+			failure = err
+		}),
+		WithOnDone(func() {
+			done = true
+		}),
+	)
+	ob.Handle(1)
+	ob.Handle(errors.New("bang"))
+	ob.OnDone()
+
+	assert.Equal(t, []interface{}{1}, items)
+	assert.EqualError(t, failure, "bang")
+	assert.True(t, done)
+
+	// Unset handlers fall back to no-ops.
+	ob = NewWith(WithOnNext(nil))
+	ob.Handle(1)
+	ob.Handle(errors.New("bang"))
+	ob.OnDone()
+}
+
+func TestZeroObserverIsSafe(t *testing.T) {
+	var ob Observer
+	ob.Handle(1)
+	ob.Handle(errors.New("bang"))
+	ob.OnNext(1)
+	ob.OnNextBatch([]interface{}{1})
+	ob.OnError(errors.New("bang"))
+	ob.OnDone()
+}
@@ -0,0 +1,125 @@
+// Package reactivestreams provides Reactive Streams style Publisher,
+// Subscriber and Subscription interfaces with demand signaling, along with
+// adapters between them and Observables, so pipelines can interoperate with
+// other streaming libraries through a common contract.
+package reactivestreams
+
+import (
+	"sync"
+
+	"github.com/reactivex/rxgo/flowable"
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/observer"
+)
+
+// Publisher provides a potentially unbounded number of items to the
+// Subscribers which subscribe to it, according to their demand.
+type Publisher interface {
+	Subscribe(Subscriber)
+}
+
+// Subscriber receives items from a Publisher. OnSubscribe is called once,
+// before any other method. No items are sent until the Subscriber calls
+// Request on the Subscription it is given, and at most one of OnError and
+// OnComplete is called at the end.
+type Subscriber interface {
+	OnSubscribe(Subscription)
+	OnNext(item interface{})
+	OnError(err error)
+	OnComplete()
+}
+
+// Subscription represents the link between one Publisher and one Subscriber.
+type Subscription interface {
+	Request(n uint64)
+	Cancel()
+}
+
+// Processor is both a Subscriber and a Publisher.
+type Processor interface {
+	Subscriber
+	Publisher
+}
+
+// Unbounded requests every remaining item of a Publisher.
+const Unbounded = flowable.Unbounded
+
+type flowablePublisher struct {
+	f flowable.Flowable
+}
+
+// FromFlowable creates a Publisher from a Flowable. Each Subscriber gets its
+// own subscription to the Flowable.
+func FromFlowable(f flowable.Flowable) Publisher {
+	return flowablePublisher{f: f}
+}
+
+// FromObservable creates a Publisher which pulls items from an Observable
+// only as its Subscriber requests them.
+func FromObservable(o observable.Observable) Publisher {
+	return FromFlowable(flowable.From(o))
+}
+
+func (p flowablePublisher) Subscribe(s Subscriber) {
+	ob := observer.New(
+		handlers.NextFunc(s.OnNext),
+		handlers.ErrFunc(s.OnError),
+		handlers.DoneFunc(s.OnComplete),
+	)
+	s.OnSubscribe(p.f.Subscribe(ob))
+}
+
+// channelSubscriber forwards the items of a Publisher to a channel, keeping
+// prefetch items requested ahead of the consumer.
+type channelSubscriber struct {
+	out      chan interface{}
+	prefetch uint64
+	once     sync.Once
+	sub      Subscription
+	received uint64
+}
+
+func (s *channelSubscriber) OnSubscribe(sub Subscription) {
+	s.sub = sub
+	sub.Request(s.prefetch)
+}
+
+func (s *channelSubscriber) OnNext(item interface{}) {
+	s.out <- item
+
+	// Top the demand back up once half of the prefetch has been consumed.
+	s.received++
+	if s.received >= (s.prefetch+1)/2 {
+		s.sub.Request(s.received)
+		s.received = 0
+	}
+}
+
+func (s *channelSubscriber) OnError(err error) {
+	s.once.Do(func() {
+		s.out <- err
+		close(s.out)
+	})
+}
+
+func (s *channelSubscriber) OnComplete() {
+	s.once.Do(func() {
+		close(s.out)
+	})
+}
+
+// ToObservable subscribes to a Publisher and returns an Observable emitting
+// its items. Up to prefetch items are requested ahead of the Observable's
+// consumer; a prefetch of zero requests one item at a time.
+func ToObservable(p Publisher, prefetch uint64) observable.Observable {
+	if prefetch == 0 {
+		prefetch = 1
+	}
+	s := &channelSubscriber{
+		out:      make(chan interface{}),
+		prefetch: prefetch,
+	}
+	go p.Subscribe(s)
+	return observable.Observable(s.out)
+}
@@ -0,0 +1,85 @@
+package reactivestreams
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/handlers"
+	"github.com/reactivex/rxgo/observable"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type testSubscriber struct {
+	initial  uint64
+	sub      Subscription
+	items    chan interface{}
+	err      error
+	complete chan struct{}
+}
+
+func newTestSubscriber(initial uint64) *testSubscriber {
+	return &testSubscriber{
+		initial:  initial,
+		items:    make(chan interface{}, 10),
+		complete: make(chan struct{}),
+	}
+}
+
+func (s *testSubscriber) OnSubscribe(sub Subscription) {
+	s.sub = sub
+	sub.Request(s.initial)
+}
+
+func (s *testSubscriber) OnNext(item interface{}) {
+	s.items <- item
+}
+
+func (s *testSubscriber) OnError(err error) {
+	s.err = err
+	close(s.complete)
+}
+
+func (s *testSubscriber) OnComplete() {
+	close(s.complete)
+}
+
+func TestFromObservableHonoursDemand(t *testing.T) {
+	s := newTestSubscriber(2)
+	FromObservable(observable.Range(0, 5)).Subscribe(s)
+
+	assert.Equal(t, 0, <-s.items)
+	assert.Equal(t, 1, <-s.items)
+	<-time.After(10 * time.Millisecond)
+	assert.Len(t, s.items, 0)
+
+	s.sub.Request(Unbounded)
+	<-s.complete
+	assert.Len(t, s.items, 3)
+	assert.Nil(t, s.err)
+}
+
+func TestFromObservableWithError(t *testing.T) {
+	s := newTestSubscriber(Unbounded)
+	FromObservable(observable.Just(1, errors.New("bang"))).Subscribe(s)
+
+	<-s.complete
+	assert.EqualError(t, s.err, "bang")
+}
+
+func TestToObservable(t *testing.T) {
+	publisher := FromObservable(observable.Range(0, 10))
+
+	nums := []int{}
+	onNext := handlers.NextFunc(func(item interface{}) {
+		if num, ok := item.(int); ok {
+			nums = append(nums, num)
+		}
+	})
+
+	sub := ToObservable(publisher, 3).Subscribe(onNext)
+	<-sub
+
+	assert.Exactly(t, []int{0, 1, 2, 3, 4, 5, 6, 7, 8, 9}, nums)
+}
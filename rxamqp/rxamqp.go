@@ -0,0 +1,234 @@
+// Package rxamqp connects AMQP 0-9-1 brokers, such as RabbitMQ, to
+// Observables. Its interfaces take a few lines to implement over an
+// amqp091-go channel, so this module doesn't depend on the client.
+package rxamqp
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reactivex/rxgo/internal/connector"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+var (
+	// ErrChannelClosed is emitted by FromAMQP when the broker stops
+	// delivering, because the channel or the connection was closed.
+	ErrChannelClosed = errors.New("rxamqp: channel closed")
+	// ErrNacked is recorded by ToAMQP when the broker refuses a publishing.
+	ErrNacked = errors.New("rxamqp: publishing nacked")
+)
+
+// Delivery is a message delivered by the broker to a consumer.
+type Delivery struct {
+	Exchange    string
+	RoutingKey  string
+	ContentType string
+	Headers     map[string]interface{}
+	DeliveryTag uint64
+	Redelivered bool
+	Body        []byte
+}
+
+// Consumer consumes a queue on an AMQP channel, without automatic
+// acknowledgement.
+type Consumer interface {
+	// Consume starts delivering the messages of queue under the consumer
+	// tag consumer. The channel is closed when the delivery stops.
+	Consume(queue, consumer string) (<-chan Delivery, error)
+	// Cancel stops the deliveries to consumer.
+	Cancel(consumer string) error
+	Ack(tag uint64, multiple bool) error
+	Nack(tag uint64, multiple, requeue bool) error
+}
+
+// Message is a Delivery emitted by FromAMQP, to acknowledge once processed.
+// It implements ack.Ackable.
+type Message struct {
+	Delivery
+	consumer Consumer
+	requeue  bool
+}
+
+// Ack acknowledges the delivery.
+func (m *Message) Ack() error {
+	return m.consumer.Ack(m.DeliveryTag, false)
+}
+
+// Nack rejects the delivery, which the broker requeues with RequeueOnNack,
+// and otherwise discards or dead-letters according to the queue.
+func (m *Message) Nack(cause error) error {
+	return m.consumer.Nack(m.DeliveryTag, false, m.requeue)
+}
+
+// ConsumeOption configures FromAMQP.
+type ConsumeOption func(*consumeConfig)
+
+type consumeConfig struct {
+	requeue bool
+}
+
+// RequeueOnNack makes the broker requeue nacked messages rather than
+// discarding or dead-lettering them. A message which always fails is then
+// redelivered forever.
+func RequeueOnNack() ConsumeOption {
+	return func(c *consumeConfig) {
+		c.requeue = true
+	}
+}
+
+// FromAMQP creates an Observable emitting a *Message for each delivery of
+// queue to consumer, until ctx is done, when it cancels the consumer and
+// completes, even while a delivery waits for a slow subscriber. It emits the error if consuming fails, and ErrChannelClosed if
+// the deliveries stop otherwise. Deliveries are only acknowledged as
+// messages are, for instance through ack.Process, and those left
+// unacknowledged are redelivered once the channel closes.
+func FromAMQP(ctx context.Context, consumer Consumer, queue, tag string, opts ...ConsumeOption) observable.Observable {
+	var c consumeConfig
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return observable.Create(func(e observable.Emitter) {
+		deliveries, err := consumer.Consume(queue, tag)
+		if err != nil {
+			e.Error(err)
+			return
+		}
+		// Cancel and complete as soon as ctx is done, which releases a
+		// Next blocked by a slow subscriber.
+		stop, cancelled := make(chan struct{}), make(chan struct{})
+		defer close(stop)
+		go func() {
+			defer close(cancelled)
+			select {
+			case <-ctx.Done():
+				consumer.Cancel(tag)
+				e.Complete()
+			case <-stop:
+			}
+		}()
+		for {
+			select {
+			case d, ok := <-deliveries:
+				if !ok {
+					e.Error(ErrChannelClosed)
+					return
+				}
+				if !e.IsDisposed() {
+					e.Next(&Message{Delivery: d, consumer: consumer, requeue: c.requeue})
+				}
+			case <-ctx.Done():
+				<-cancelled
+				return
+			}
+			if e.IsDisposed() && ctx.Err() == nil {
+				consumer.Cancel(tag)
+				return
+			}
+		}
+	})
+}
+
+// Publishing is a message to publish.
+type Publishing struct {
+	Exchange    string
+	RoutingKey  string
+	ContentType string
+	Headers     map[string]interface{}
+	Body        []byte
+}
+
+// Confirmation is the pending confirmation of a publishing by the broker,
+// like amqp091-go's DeferredConfirmation.
+type Confirmation interface {
+	// WaitContext blocks until the broker confirms the publishing, and
+	// reports whether it acknowledged it.
+	WaitContext(ctx context.Context) (bool, error)
+}
+
+// Publisher publishes on an AMQP channel.
+type Publisher interface {
+	// Publish publishes msg and returns its Confirmation when the channel
+	// is in confirm mode, and nil otherwise.
+	Publish(ctx context.Context, msg Publishing) (Confirmation, error)
+}
+
+// PublishOption configures ToAMQP.
+type PublishOption func(*publishConfig)
+
+type publishConfig struct {
+	inFlight int
+}
+
+// MaxInFlight sets how many publishings ToAMQP may wait confirmations for at
+// once, 1 by default. A larger window raises the throughput in confirm
+// mode, at the cost of more publishings to retry after a failure.
+func MaxInFlight(n uint) PublishOption {
+	return func(c *publishConfig) {
+		if n > 0 {
+			c.inFlight = int(n)
+		}
+	}
+}
+
+// ToAMQP publishes the items of o with publisher: Publishings as they are,
+// with exchange and routingKey filled in when both are empty, []byte and
+// strings as the body of a publishing to exchange with routingKey, and any
+// other item as its JSON encoding. In confirm mode, it waits for each
+// publishing to be confirmed, recording ErrNacked if the broker refuses it.
+// The returned channel receives the Subscription, recording the error of o
+// or the first publish or confirm error, which stops the publishing.
+func ToAMQP(ctx context.Context, publisher Publisher, exchange, routingKey string, o observable.Observable, opts ...PublishOption) <-chan subscription.Subscription {
+	c := publishConfig{inFlight: 1}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var pending []Confirmation
+	// confirm waits for the oldest pending confirmations until at most n
+	// are left.
+	confirm := func(n int) error {
+		for len(pending) > n {
+			acked, err := pending[0].WaitContext(ctx)
+			pending = pending[1:]
+			if err != nil {
+				return err
+			}
+			if !acked {
+				return ErrNacked
+			}
+		}
+		return nil
+	}
+
+	return connector.Sink(o, func(item interface{}) error {
+		msg, ok := item.(Publishing)
+		if ok {
+			if msg.Exchange == "" && msg.RoutingKey == "" {
+				msg.Exchange, msg.RoutingKey = exchange, routingKey
+			}
+		} else {
+			msg = Publishing{Exchange: exchange, RoutingKey: routingKey}
+			switch item.(type) {
+			case []byte, string:
+			default:
+				msg.ContentType = "application/json"
+			}
+			var err error
+			if msg.Body, err = connector.Payload(item); err != nil {
+				return err
+			}
+		}
+		if err := confirm(c.inFlight - 1); err != nil {
+			return err
+		}
+		conf, err := publisher.Publish(ctx, msg)
+		if conf != nil {
+			pending = append(pending, conf)
+		}
+		return err
+	}, func() error {
+		return confirm(0)
+	})
+}
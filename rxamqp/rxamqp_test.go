@@ -0,0 +1,174 @@
+package rxamqp
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/ack"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConsumer struct {
+	deliveries chan Delivery
+	err        error
+
+	mu        sync.Mutex
+	acked     []uint64
+	nacked    []uint64
+	requeued  []bool
+	cancelled []string
+}
+
+func (c *fakeConsumer) Consume(queue, consumer string) (<-chan Delivery, error) {
+	return c.deliveries, c.err
+}
+
+func (c *fakeConsumer) Cancel(consumer string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cancelled = append(c.cancelled, consumer)
+	return nil
+}
+
+func (c *fakeConsumer) Ack(tag uint64, multiple bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.acked = append(c.acked, tag)
+	return nil
+}
+
+func (c *fakeConsumer) Nack(tag uint64, multiple, requeue bool) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.nacked = append(c.nacked, tag)
+	c.requeued = append(c.requeued, requeue)
+	return nil
+}
+
+func newFakeConsumer(tags ...uint64) *fakeConsumer {
+	c := &fakeConsumer{deliveries: make(chan Delivery, len(tags))}
+	for _, tag := range tags {
+		c.deliveries <- Delivery{RoutingKey: "orders", DeliveryTag: tag}
+	}
+	return c
+}
+
+func TestFromAMQP(t *testing.T) {
+	consumer := newFakeConsumer(1, 2, 3)
+	close(consumer.deliveries)
+
+	sub := <-ack.Process(FromAMQP(context.Background(), consumer, "orders", "worker-1", RequeueOnNack()), func(item interface{}) error {
+		if item.(*Message).DeliveryTag == 2 {
+			return errors.New("poison")
+		}
+		return nil
+	})
+	assert.Equal(t, ErrChannelClosed, sub.Err())
+	assert.Equal(t, []uint64{1, 3}, consumer.acked)
+	assert.Equal(t, []uint64{2}, consumer.nacked)
+	assert.Equal(t, []bool{true}, consumer.requeued)
+}
+
+func TestFromAMQPCancel(t *testing.T) {
+	consumer := newFakeConsumer()
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromAMQP(ctx, consumer, "orders", "worker-1")
+	cancel()
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+	assert.Equal(t, []string{"worker-1"}, consumer.cancelled)
+}
+
+func TestFromAMQPCancelWhileBlocked(t *testing.T) {
+	consumer := newFakeConsumer(1, 2, 3)
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromAMQP(ctx, consumer, "orders", "worker-1")
+
+	// With nothing reading, the first delivery blocks until the
+	// cancellation releases it.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		consumer.mu.Lock()
+		cancelled := len(consumer.cancelled)
+		consumer.mu.Unlock()
+		if cancelled == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("consumer not cancelled")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	for range o {
+	}
+}
+
+func TestFromAMQPConsumeError(t *testing.T) {
+	consumer := &fakeConsumer{err: errors.New("NOT_FOUND - no queue 'orders'")}
+	_, err := FromAMQP(context.Background(), consumer, "orders", "worker-1").ToSlice()
+	assert.EqualError(t, err, "NOT_FOUND - no queue 'orders'")
+}
+
+type fakeConfirmation struct {
+	publisher *fakePublisher
+	acked     bool
+}
+
+func (c fakeConfirmation) WaitContext(ctx context.Context) (bool, error) {
+	c.publisher.waits++
+	return c.acked, nil
+}
+
+type fakePublisher struct {
+	published []Publishing
+	nack      int
+	confirm   bool
+	waits     int
+	// maxInFlight is the largest number of publishings seen unconfirmed.
+	maxInFlight int
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, msg Publishing) (Confirmation, error) {
+	p.published = append(p.published, msg)
+	if !p.confirm {
+		return nil, nil
+	}
+	if inFlight := len(p.published) - p.waits; inFlight > p.maxInFlight {
+		p.maxInFlight = inFlight
+	}
+	return fakeConfirmation{publisher: p, acked: len(p.published) != p.nack}, nil
+}
+
+func TestToAMQP(t *testing.T) {
+	p := &fakePublisher{}
+	sub := <-ToAMQP(context.Background(), p, "events", "orders", observable.Just(
+		"a", Publishing{Exchange: "audit", Body: []byte("b")}, map[string]int{"c": 1},
+	))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []Publishing{
+		{Exchange: "events", RoutingKey: "orders", Body: []byte("a")},
+		{Exchange: "audit", Body: []byte("b")},
+		{Exchange: "events", RoutingKey: "orders", ContentType: "application/json", Body: []byte(`{"c":1}`)},
+	}, p.published)
+}
+
+func TestToAMQPConfirms(t *testing.T) {
+	p := &fakePublisher{confirm: true}
+	sub := <-ToAMQP(context.Background(), p, "events", "orders", observable.Just("a", "b", "c", "d", "e"), MaxInFlight(2))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, 5, p.waits)
+	assert.Equal(t, 2, p.maxInFlight)
+
+	p = &fakePublisher{confirm: true, nack: 2}
+	sub = <-ToAMQP(context.Background(), p, "events", "orders", observable.Just("a", "b", "c", "d"))
+	assert.Equal(t, ErrNacked, sub.Err())
+	assert.Len(t, p.published, 2)
+}
@@ -0,0 +1,177 @@
+// Package rxgrpc bridges gRPC streams and Observables. Its Receiver and
+// Sender interfaces are satisfied by grpc.ClientStream and grpc.ServerStream,
+// and so by every generated streaming client and server, without this
+// module depending on gRPC.
+//
+// Backpressure maps onto gRPC flow control: FromGRPCStream only receives the
+// next message once the previous one has been taken downstream, and
+// ToGRPCStream only takes the next item once SendMsg, which blocks while the
+// flow control window is exhausted, has returned.
+package rxgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"sync/atomic"
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Receiver is the receiving side of a gRPC stream.
+type Receiver interface {
+	Context() context.Context
+	RecvMsg(m interface{}) error
+}
+
+// Sender is the sending side of a gRPC stream.
+type Sender interface {
+	Context() context.Context
+	SendMsg(m interface{}) error
+}
+
+// ErrMessageTimeout is the error emitted when a message takes longer than
+// the MessageTimeout to be received or sent.
+var ErrMessageTimeout = errors.New("rxgrpc: message timeout")
+
+// errNilCancel is emitted when MessageTimeout is given no cancel function.
+var errNilCancel = errors.New("rxgrpc: MessageTimeout requires a cancel function")
+
+// Option configures FromGRPCStream and ToGRPCStream.
+type Option func(*config)
+
+type config struct {
+	timeout time.Duration
+	cancel  context.CancelFunc
+	// expired is set once a timeout cancelled the stream.
+	expired *int32
+	err     error
+}
+
+// MessageTimeout bounds the time to receive or send each message to d. When
+// it expires, cancel, which should cancel the context of the stream, is
+// called to abort the pending call, and the stream fails with
+// ErrMessageTimeout. A nil cancel makes the stream fail straight away.
+func MessageTimeout(d time.Duration, cancel context.CancelFunc) Option {
+	return func(c *config) {
+		if cancel == nil {
+			c.err = errNilCancel
+			return
+		}
+		c.timeout, c.cancel = d, cancel
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{expired: new(int32)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// call runs f, cancelling the stream if it outlasts the timeout. Once the
+// stream has been cancelled that way, errors are reported as
+// ErrMessageTimeout; a call which succeeded regardless returns nil.
+func (c config) call(f func() error) error {
+	if c.timeout <= 0 {
+		return f()
+	}
+	timer := time.AfterFunc(c.timeout, func() {
+		atomic.StoreInt32(c.expired, 1)
+		c.cancel()
+	})
+	err := f()
+	timer.Stop()
+	if err != nil && c.timedOut() {
+		return ErrMessageTimeout
+	}
+	return err
+}
+
+func (c config) timedOut() bool {
+	return atomic.LoadInt32(c.expired) == 1
+}
+
+// FromGRPCStream creates an Observable emitting the messages received on
+// stream, each one decoded into a fresh message returned by newMsg, such as
+// func() interface{} { return new(pb.Reply) }. It completes at the end of
+// the stream or once the stream context is cancelled, and fails with any
+// other error.
+func FromGRPCStream(stream Receiver, newMsg func() interface{}, opts ...Option) observable.Observable {
+	c := newConfig(opts)
+	return observable.Create(func(e observable.Emitter) {
+		if c.err != nil {
+			e.Error(c.err)
+			return
+		}
+		for {
+			m := newMsg()
+			err := c.call(func() error { return stream.RecvMsg(m) })
+			switch {
+			case err == ErrMessageTimeout:
+				e.Error(err)
+				return
+			case err == io.EOF:
+				e.Complete()
+				return
+			case err != nil:
+				if stream.Context().Err() == context.Canceled {
+					e.Complete()
+				} else {
+					e.Error(err)
+				}
+				return
+			}
+			if e.IsDisposed() {
+				return
+			}
+			e.Next(m)
+		}
+	})
+}
+
+// ToGRPCStream sends the items of o on stream. Once o completes it closes
+// the sending side of client streams, which implement CloseSend. The
+// returned channel receives the Subscription, recording the error of o, the
+// first send error or ErrMessageTimeout. Sending stops early if the stream
+// context is done.
+func ToGRPCStream(stream Sender, o observable.Observable, opts ...Option) <-chan subscription.Subscription {
+	c := newConfig(opts)
+	done := make(chan subscription.Subscription, 1)
+	go func() {
+		sub := subscription.New().Subscribe()
+		sub.Error = c.err
+		ctx := stream.Context()
+	OuterLoop:
+		for sub.Error == nil {
+			select {
+			case <-ctx.Done():
+				sub.Error = ctx.Err()
+				if c.timedOut() {
+					sub.Error = ErrMessageTimeout
+				}
+				break OuterLoop
+			case item, ok := <-o:
+				if !ok {
+					if closer, ok := stream.(interface{ CloseSend() error }); ok {
+						sub.Error = closer.CloseSend()
+					}
+					break OuterLoop
+				}
+				if err, isErr := item.(error); isErr {
+					sub.Error = err
+					break OuterLoop
+				}
+				if err := c.call(func() error { return stream.SendMsg(item) }); err != nil {
+					sub.Error = err
+					break OuterLoop
+				}
+			}
+		}
+		done <- sub.Unsubscribe()
+	}()
+	return done
+}
@@ -0,0 +1,149 @@
+package rxgrpc
+
+import (
+	"context"
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type reply struct {
+	Value int
+}
+
+// fakeStream is an in-memory stream of *reply messages. Received messages
+// are read from in until it is closed, then recvErr is returned; sent
+// messages are appended to sent. A nil entry in in blocks until the
+// context is done.
+type fakeStream struct {
+	ctx     context.Context
+	in      chan *reply
+	recvErr error
+	sent    []interface{}
+	sendErr error
+	closed  bool
+}
+
+func newFakeStream(ctx context.Context, replies ...*reply) *fakeStream {
+	s := &fakeStream{ctx: ctx, in: make(chan *reply, len(replies)), recvErr: io.EOF}
+	for _, r := range replies {
+		s.in <- r
+	}
+	close(s.in)
+	return s
+}
+
+func (s *fakeStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *fakeStream) RecvMsg(m interface{}) error {
+	r, ok := <-s.in
+	if !ok {
+		return s.recvErr
+	}
+	if r == nil {
+		<-s.ctx.Done()
+		return s.ctx.Err()
+	}
+	*m.(*reply) = *r
+	return nil
+}
+
+func (s *fakeStream) SendMsg(m interface{}) error {
+	if m == "slow" {
+		<-s.ctx.Done()
+		return s.ctx.Err()
+	}
+	s.sent = append(s.sent, m)
+	return s.sendErr
+}
+
+func (s *fakeStream) CloseSend() error {
+	s.closed = true
+	return nil
+}
+
+func newReply() interface{} {
+	return new(reply)
+}
+
+func TestFromGRPCStream(t *testing.T) {
+	stream := newFakeStream(context.Background(), &reply{1}, &reply{2})
+	items, err := FromGRPCStream(stream, newReply).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{&reply{1}, &reply{2}}, items)
+
+	stream = newFakeStream(context.Background(), &reply{1})
+	stream.recvErr = errors.New("unavailable")
+	items, err = FromGRPCStream(stream, newReply).ToSlice()
+	assert.EqualError(t, err, "unavailable")
+	assert.Equal(t, []interface{}{&reply{1}}, items)
+}
+
+func TestFromGRPCStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromGRPCStream(newFakeStream(ctx, &reply{1}, nil), newReply)
+	assert.Equal(t, &reply{1}, <-o)
+	cancel()
+	_, ok := <-o
+	assert.False(t, ok)
+}
+
+func TestFromGRPCStreamTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	_, err := FromGRPCStream(newFakeStream(ctx, nil), newReply, MessageTimeout(time.Millisecond, cancel)).ToSlice()
+	assert.Equal(t, ErrMessageTimeout, err)
+}
+
+func TestToGRPCStream(t *testing.T) {
+	stream := newFakeStream(context.Background())
+	sub := <-ToGRPCStream(stream, observable.Just(&reply{1}, &reply{2}))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []interface{}{&reply{1}, &reply{2}}, stream.sent)
+	assert.True(t, stream.closed)
+
+	stream = newFakeStream(context.Background())
+	sub = <-ToGRPCStream(stream, observable.Just(&reply{1}, errors.New("bang")))
+	assert.EqualError(t, sub.Err(), "bang")
+	assert.False(t, stream.closed)
+
+	stream = newFakeStream(context.Background())
+	stream.sendErr = errors.New("reset")
+	sub = <-ToGRPCStream(stream, observable.Just(&reply{1}, &reply{2}))
+	assert.EqualError(t, sub.Err(), "reset")
+	assert.Len(t, stream.sent, 1)
+}
+
+func TestToGRPCStreamTimeout(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := newFakeStream(ctx)
+	sub := <-ToGRPCStream(stream, observable.Just("slow"), MessageTimeout(time.Millisecond, cancel))
+	assert.Equal(t, ErrMessageTimeout, sub.Err())
+
+	sub = <-ToGRPCStream(stream, observable.Never())
+	assert.Equal(t, context.Canceled, sub.Err())
+}
+
+func TestMessageTimeoutNilCancel(t *testing.T) {
+	_, err := FromGRPCStream(newFakeStream(context.Background()), newReply, MessageTimeout(time.Second, nil)).ToSlice()
+	assert.Equal(t, errNilCancel, err)
+	sub := <-ToGRPCStream(newFakeStream(context.Background()), observable.Just(1), MessageTimeout(time.Second, nil))
+	assert.Equal(t, errNilCancel, sub.Err())
+}
+
+func TestMessageTimeoutLateSuccess(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	c := newConfig([]Option{MessageTimeout(time.Millisecond, cancel)})
+	err := c.call(func() error {
+		<-ctx.Done()
+		return nil
+	})
+	assert.Nil(t, err)
+	err = c.call(func() error { return ctx.Err() })
+	assert.Equal(t, ErrMessageTimeout, err)
+}
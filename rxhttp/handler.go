@@ -0,0 +1,163 @@
+package rxhttp
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/reactivex/rxgo/bus"
+	"github.com/reactivex/rxgo/observable"
+)
+
+// Format is the wire format Handler streams items in.
+type Format uint32
+
+const (
+	// FormatAuto picks FormatSSE for clients accepting text/event-stream
+	// and FormatNDJSON otherwise.
+	FormatAuto Format = iota
+	// FormatSSE streams items as Server-Sent Events.
+	FormatSSE
+	// FormatNDJSON streams items as newline-delimited JSON.
+	FormatNDJSON
+)
+
+// HandlerOption configures Handler.
+type HandlerOption func(*handlerConfig)
+
+type handlerConfig struct {
+	format   Format
+	strategy observable.BackpressureStrategy
+}
+
+// WithFormat makes Handler stream in format whatever the client accepts.
+func WithFormat(format Format) HandlerOption {
+	return func(c *handlerConfig) {
+		c.format = format
+	}
+}
+
+// SlowClients sets the BackpressureStrategy applied to each client which
+// can't keep up. It defaults to buffering up to 64 items and dropping the
+// oldest ones beyond. With observable.Error, a client falling behind is sent
+// an error and disconnected; with a blocking strategy it slows down every
+// client.
+func SlowClients(strategy observable.BackpressureStrategy) HandlerOption {
+	return func(c *handlerConfig) {
+		c.strategy = strategy
+	}
+}
+
+// Handler returns an http.Handler streaming the items of o to every client
+// connected while they are emitted, as Server-Sent Events or chunked
+// NDJSON. o is consumed straight away, whether clients are connected or
+// not. Each client gets its own subscription, which is dropped as soon as
+// the client disconnects. An error is sent to every client as a final
+// "error" event, or an {"error": ...} line, and completion ends the
+// responses.
+func Handler(o observable.Observable, opts ...HandlerOption) http.Handler {
+	config := handlerConfig{
+		strategy: observable.BackpressureStrategy{Capacity: 64, Overflow: observable.OverflowDropOldest},
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+	topic := bus.NewBus().Register("handler", o)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		format := config.format
+		if format == FormatAuto {
+			format = FormatNDJSON
+			if strings.Contains(r.Header.Get("Accept"), "text/event-stream") {
+				format = FormatSSE
+			}
+		}
+		// Subscribe before sending the headers, so that a client which has
+		// received them doesn't miss any item.
+		sub := topic.Subscribe()
+		items := sub.OnBackpressure(config.strategy)
+		defer func() {
+			topic.Unsubscribe(sub)
+			// Let the backpressure stage terminate.
+			go func() {
+				for range items {
+				}
+			}()
+		}()
+
+		flusher, _ := w.(http.Flusher)
+		if format == FormatSSE {
+			w.Header().Set("Content-Type", "text/event-stream")
+			w.Header().Set("Cache-Control", "no-cache")
+		} else {
+			w.Header().Set("Content-Type", "application/x-ndjson")
+		}
+		w.WriteHeader(http.StatusOK)
+		if flusher != nil {
+			flusher.Flush()
+		}
+
+		for {
+			select {
+			case <-r.Context().Done():
+				return
+			case item, ok := <-items:
+				if !ok {
+					return
+				}
+				err := writeItem(w, format, item)
+				if flusher != nil {
+					flusher.Flush()
+				}
+				if _, isErr := item.(error); isErr || err != nil {
+					return
+				}
+			}
+		}
+	})
+}
+
+// writeItem writes item to w in format.
+func writeItem(w io.Writer, format Format, item interface{}) error {
+	if format == FormatNDJSON {
+		if err, isErr := item.(error); isErr {
+			item = map[string]string{"error": err.Error()}
+		}
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(w, "%s\n", data)
+		return err
+	}
+
+	var event Event
+	switch item := item.(type) {
+	case Event:
+		event = item
+	case error:
+		event = Event{Event: "error", Data: item.Error()}
+	default:
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		event = Event{Data: string(data)}
+	}
+
+	var b strings.Builder
+	if event.ID != "" {
+		fmt.Fprintf(&b, "id: %s\n", event.ID)
+	}
+	if event.Event != "" {
+		fmt.Fprintf(&b, "event: %s\n", event.Event)
+	}
+	for _, line := range strings.Split(event.Data, "\n") {
+		fmt.Fprintf(&b, "data: %s\n", line)
+	}
+	b.WriteString("\n")
+	_, err := io.WriteString(w, b.String())
+	return err
+}
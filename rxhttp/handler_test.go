@@ -0,0 +1,95 @@
+package rxhttp
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+func readLines(t *testing.T, res *http.Response, n int) []string {
+	var lines []string
+	scanner := bufio.NewScanner(res.Body)
+	for len(lines) < n && scanner.Scan() {
+		lines = append(lines, scanner.Text())
+	}
+	return lines
+}
+
+func TestHandlerSSE(t *testing.T) {
+	source := make(chan interface{})
+	server := httptest.NewServer(Handler(observable.Observable(source)))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	req.Header.Set("Accept", "text/event-stream")
+	res, err := http.DefaultClient.Do(req)
+	assert.Nil(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+
+	source <- map[string]int{"price": 10}
+	source <- Event{ID: "7", Event: "note", Data: "two\nlines"}
+	source <- errors.New("bang")
+	assert.Equal(t, []string{
+		`data: {"price":10}`, "",
+		"id: 7", "event: note", "data: two", "data: lines", "",
+		"event: error", "data: bang", "",
+	}, readLines(t, res, 10))
+	close(source)
+}
+
+func TestHandlerNDJSON(t *testing.T) {
+	source := make(chan interface{})
+	server := httptest.NewServer(Handler(observable.Observable(source)))
+	defer server.Close()
+
+	res, err := http.Get(server.URL)
+	assert.Nil(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, "application/x-ndjson", res.Header.Get("Content-Type"))
+
+	source <- 1
+	source <- "two"
+	close(source)
+	assert.Equal(t, []string{"1", `"two"`}, readLines(t, res, 3))
+
+	res, err = http.Get(server.URL)
+	assert.Nil(t, err)
+	assert.Empty(t, readLines(t, res, 1))
+}
+
+func TestHandlerDisconnect(t *testing.T) {
+	source := make(chan interface{})
+	server := httptest.NewServer(Handler(observable.Observable(source), SlowClients(observable.Block), WithFormat(FormatSSE)))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	res, err := http.DefaultClient.Do(req.WithContext(ctx))
+	assert.Nil(t, err)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	cancel()
+	res.Body.Close()
+
+	// The blocking client is gone, so the source isn't held back.
+	sent := make(chan struct{})
+	go func() {
+		for i := 0; i < 3; i++ {
+			source <- i
+		}
+		close(sent)
+	}()
+	select {
+	case <-sent:
+	case <-time.After(time.Second):
+		t.Fatal("source blocked by a disconnected client")
+	}
+	close(source)
+}
@@ -0,0 +1,109 @@
+// Package rxhttp bridges HTTP and Observables: requests as sources of
+// responses or of streamed body chunks.
+//
+// Observables have no unsubscription signal, so requests are cancelled the
+// Go way: cancel the context of the request to abort it, which terminates
+// the Observable without an error.
+package rxhttp
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net/http"
+
+	"github.com/reactivex/rxgo/observable"
+)
+
+func clientOrDefault(client *http.Client) *http.Client {
+	if client == nil {
+		return http.DefaultClient
+	}
+	return client
+}
+
+// FromHTTPRequest creates an Observable which sends req with client, or
+// http.DefaultClient if nil, and emits the *http.Response once its body has
+// been read in full, so that the connection is released whatever the
+// subscriber does with it. Responses are emitted whatever their status
+// code; a failed request emits its error.
+func FromHTTPRequest(client *http.Client, req *http.Request) observable.Observable {
+	return observable.Create(func(e observable.Emitter) {
+		res, err := clientOrDefault(client).Do(req)
+		if err != nil {
+			if req.Context().Err() == nil {
+				e.Error(err)
+			} else {
+				e.Complete()
+			}
+			return
+		}
+		body, err := io.ReadAll(res.Body)
+		res.Body.Close()
+		if err != nil {
+			if req.Context().Err() == nil {
+				e.Error(err)
+			} else {
+				e.Complete()
+			}
+			return
+		}
+		res.Body = io.NopCloser(bytes.NewReader(body))
+		e.Next(res)
+		e.Complete()
+	})
+}
+
+// FromHTTPStream creates an Observable which sends req with client, or
+// http.DefaultClient if nil, and emits the tokens of the response body as
+// they arrive, split by an optional bufio.SplitFunc which defaults to
+// bufio.ScanLines. Each token is a fresh []byte. A response with a status
+// code other than 2xx emits a StatusError.
+func FromHTTPStream(client *http.Client, req *http.Request, split ...bufio.SplitFunc) observable.Observable {
+	return observable.Create(func(e observable.Emitter) {
+		fail := func(err error) {
+			if req.Context().Err() == nil {
+				e.Error(err)
+			} else {
+				e.Complete()
+			}
+		}
+
+		res, err := clientOrDefault(client).Do(req)
+		if err != nil {
+			fail(err)
+			return
+		}
+		defer res.Body.Close()
+		if res.StatusCode < 200 || res.StatusCode > 299 {
+			e.Error(&StatusError{Response: res})
+			return
+		}
+
+		scanner := bufio.NewScanner(res.Body)
+		if len(split) > 0 {
+			scanner.Split(split[0])
+		}
+		for scanner.Scan() {
+			if e.IsDisposed() {
+				return
+			}
+			e.Next(append([]byte(nil), scanner.Bytes()...))
+		}
+		if err := scanner.Err(); err != nil {
+			fail(err)
+			return
+		}
+		e.Complete()
+	})
+}
+
+// StatusError is the error emitted for a response with an unexpected status
+// code.
+type StatusError struct {
+	Response *http.Response
+}
+
+func (e *StatusError) Error() string {
+	return "rxhttp: unexpected status " + e.Response.Status
+}
@@ -0,0 +1,92 @@
+package rxhttp
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromHTTPRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+		fmt.Fprint(w, "short and stout")
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	items, err := FromHTTPRequest(nil, req).ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, items, 1)
+
+	res := items[0].(*http.Response)
+	assert.Equal(t, http.StatusTeapot, res.StatusCode)
+	body, _ := io.ReadAll(res.Body)
+	assert.Equal(t, "short and stout", string(body))
+}
+
+func TestFromHTTPRequestError(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	_, err := FromHTTPRequest(server.Client(), req).ToSlice()
+	assert.NotNil(t, err)
+}
+
+func TestFromHTTPStream(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		for i := 0; i < 3; i++ {
+			fmt.Fprintf(w, "line %d\n", i)
+			w.(http.Flusher).Flush()
+		}
+	}))
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	items, err := FromHTTPStream(nil, req).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{[]byte("line 0"), []byte("line 1"), []byte("line 2")}, items)
+
+	items, err = FromHTTPStream(nil, req, bufio.ScanWords).ToSlice()
+	assert.Nil(t, err)
+	assert.Len(t, items, 6)
+}
+
+func TestFromHTTPStreamStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	_, err := FromHTTPStream(nil, req).ToSlice()
+	assert.EqualError(t, err, "rxhttp: unexpected status 404 Not Found")
+	assert.Equal(t, http.StatusNotFound, err.(*StatusError).Response.StatusCode)
+}
+
+func TestFromHTTPStreamCancel(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "first\n")
+		w.(http.Flusher).Flush()
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer server.Close()
+	defer close(release)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	req, _ := http.NewRequest("GET", server.URL, nil)
+	o := FromHTTPStream(nil, req.WithContext(ctx))
+
+	assert.Equal(t, []byte("first"), <-o)
+	cancel()
+	_, ok := <-o
+	assert.False(t, ok)
+}
@@ -0,0 +1,222 @@
+package rxhttp
+
+import (
+	"bufio"
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+)
+
+// Event is a Server-Sent Event.
+type Event struct {
+	ID    string
+	Event string
+	Data  string
+}
+
+// SSEOption configures FromSSE.
+type SSEOption func(*sseConfig)
+
+type sseConfig struct {
+	client     *http.Client
+	ctx        context.Context
+	header     http.Header
+	backoff    time.Duration
+	maxBackoff time.Duration
+	maxRetries int
+}
+
+// SSEClient makes FromSSE connect with client instead of http.DefaultClient.
+func SSEClient(client *http.Client) SSEOption {
+	return func(c *sseConfig) {
+		c.client = client
+	}
+}
+
+// SSEContext makes FromSSE stop, and complete, once ctx is done.
+func SSEContext(ctx context.Context) SSEOption {
+	return func(c *sseConfig) {
+		c.ctx = ctx
+	}
+}
+
+// SSEHeader adds a header to every connection request of FromSSE.
+func SSEHeader(key, value string) SSEOption {
+	return func(c *sseConfig) {
+		c.header.Add(key, value)
+	}
+}
+
+// SSEBackoff sets the delay before the first reconnection, doubled after
+// each failed attempt up to max. It defaults to one second up to 30
+// seconds. A retry field sent by the server replaces the initial delay.
+func SSEBackoff(initial, max time.Duration) SSEOption {
+	return func(c *sseConfig) {
+		c.backoff, c.maxBackoff = initial, max
+	}
+}
+
+// SSEMaxRetries bounds the number of consecutive failed reconnections,
+// after which FromSSE emits the last error. A negative n, the default,
+// retries forever.
+func SSEMaxRetries(n int) SSEOption {
+	return func(c *sseConfig) {
+		c.maxRetries = n
+	}
+}
+
+// FromSSE creates an Observable which connects to the Server-Sent Events
+// endpoint at url and emits each Event it receives. When the stream breaks
+// it reconnects after a backoff, sending the ID of the last event in a
+// Last-Event-ID header so the server can resume. The Observable completes
+// when the server answers 204 No Content or the context given with
+// SSEContext is done, and fails on any other non-200 status.
+func FromSSE(url string, opts ...SSEOption) observable.Observable {
+	config := sseConfig{
+		client:     http.DefaultClient,
+		ctx:        context.Background(),
+		header:     make(http.Header),
+		backoff:    time.Second,
+		maxBackoff: 30 * time.Second,
+		maxRetries: -1,
+	}
+	for _, opt := range opts {
+		opt(&config)
+	}
+
+	return observable.Create(func(e observable.Emitter) {
+		req, err := http.NewRequest("GET", url, nil)
+		if err != nil {
+			e.Error(err)
+			return
+		}
+		req = req.WithContext(config.ctx)
+
+		s := &sseStream{config: config, emitter: e, retry: config.backoff}
+		failures, delay := 0, s.retry
+		for {
+			received, err := s.connect(req)
+			if config.ctx.Err() != nil || e.IsDisposed() || err == errNoContent {
+				e.Complete()
+				return
+			}
+			if _, ok := err.(*StatusError); ok {
+				e.Error(err)
+				return
+			}
+
+			if received {
+				failures, delay = 0, s.retry
+			} else if failures++; config.maxRetries >= 0 && failures > config.maxRetries {
+				if err == nil {
+					err = errNoEvents
+				}
+				e.Error(err)
+				return
+			}
+			select {
+			case <-config.ctx.Done():
+				e.Complete()
+				return
+			case <-time.After(delay):
+			}
+			if !received {
+				if delay *= 2; delay > config.maxBackoff {
+					delay = config.maxBackoff
+				}
+			}
+		}
+	})
+}
+
+type sseError string
+
+func (e sseError) Error() string {
+	return string(e)
+}
+
+const (
+	errNoContent = sseError("rxhttp: no content")
+	errNoEvents  = sseError("rxhttp: connection closed without events")
+)
+
+// sseStream holds the state of FromSSE carried across connections.
+type sseStream struct {
+	config      sseConfig
+	emitter     observable.Emitter
+	lastEventID string
+	retry       time.Duration
+}
+
+// connect reads one connection to the end, and reports whether it received
+// any event.
+func (s *sseStream) connect(template *http.Request) (bool, error) {
+	req := template.Clone(s.config.ctx)
+	for key, values := range s.config.header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Accept", "text/event-stream")
+	req.Header.Set("Cache-Control", "no-cache")
+	if s.lastEventID != "" {
+		req.Header.Set("Last-Event-ID", s.lastEventID)
+	}
+
+	res, err := s.config.client.Do(req)
+	if err != nil {
+		return false, err
+	}
+	defer res.Body.Close()
+	switch {
+	case res.StatusCode == http.StatusNoContent:
+		return false, errNoContent
+	case res.StatusCode != http.StatusOK:
+		return false, &StatusError{Response: res}
+	}
+
+	received := false
+	var data strings.Builder
+	var event Event
+	scanner := bufio.NewScanner(res.Body)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			// Dispatch the event, if it has any data.
+			if data.Len() > 0 {
+				event.ID = s.lastEventID
+				event.Data = strings.TrimSuffix(data.String(), "\n")
+				if s.emitter.IsDisposed() {
+					return true, nil
+				}
+				s.emitter.Next(event)
+				received = true
+			}
+			data.Reset()
+			event = Event{}
+			continue
+		}
+		field, value := line, ""
+		if i := strings.IndexByte(line, ':'); i >= 0 {
+			field, value = line[:i], strings.TrimPrefix(line[i+1:], " ")
+		}
+		switch field {
+		case "data":
+			data.WriteString(value)
+			data.WriteByte('\n')
+		case "event":
+			event.Event = value
+		case "id":
+			if !strings.ContainsRune(value, 0) {
+				s.lastEventID = value
+			}
+		case "retry":
+			if ms, err := strconv.Atoi(value); err == nil && ms >= 0 {
+				s.retry = time.Duration(ms) * time.Millisecond
+			}
+		}
+	}
+	return received, scanner.Err()
+}
@@ -0,0 +1,86 @@
+package rxhttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromSSE(t *testing.T) {
+	var mu sync.Mutex
+	var lastEventIDs []string
+	connections := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		connections++
+		n := connections
+		lastEventIDs = append(lastEventIDs, r.Header.Get("Last-Event-ID"))
+		mu.Unlock()
+
+		assert.Equal(t, "text/event-stream", r.Header.Get("Accept"))
+		assert.Equal(t, "secret", r.Header.Get("Authorization"))
+		switch n {
+		case 1:
+			w.Header().Set("Content-Type", "text/event-stream")
+			fmt.Fprint(w, ": comment\nretry: 5\n\nid: 1\nevent: price\ndata: 10\n\n")
+			fmt.Fprint(w, "id: 2\ndata: first line\ndata:second line\n\n")
+		case 2:
+			// A broken connection without events.
+		case 3:
+			fmt.Fprint(w, "data: resumed\n\n")
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	items, err := FromSSE(server.URL,
+		SSEHeader("Authorization", "secret"),
+		SSEBackoff(time.Millisecond, 10*time.Millisecond),
+	).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		Event{ID: "1", Event: "price", Data: "10"},
+		Event{ID: "2", Data: "first line\nsecond line"},
+		Event{ID: "2", Data: "resumed"},
+	}, items)
+	assert.Equal(t, []string{"", "2", "2", "2"}, lastEventIDs)
+}
+
+func TestFromSSEStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := FromSSE(server.URL, SSEClient(server.Client())).ToSlice()
+	assert.IsType(t, &StatusError{}, err)
+}
+
+func TestFromSSEMaxRetries(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer server.Close()
+
+	_, err := FromSSE(server.URL, SSEBackoff(time.Millisecond, time.Millisecond), SSEMaxRetries(2)).ToSlice()
+	assert.EqualError(t, err, "rxhttp: connection closed without events")
+}
+
+func TestFromSSEContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "data: hello\n\n")
+		w.(http.Flusher).Flush()
+		<-r.Context().Done()
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromSSE(server.URL, SSEContext(ctx))
+	assert.Equal(t, Event{Data: "hello"}, <-o)
+	cancel()
+	_, ok := <-o
+	assert.False(t, ok)
+}
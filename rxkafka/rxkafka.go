@@ -0,0 +1,249 @@
+// Package rxkafka connects Kafka topics to Observables. Its Consumer and
+// Producer interfaces are small enough to be implemented over any Kafka
+// client in a few lines, so this module doesn't depend on one.
+package rxkafka
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/reactivex/rxgo/internal/connector"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Record is a Kafka message, with its position in its partition.
+type Record struct {
+	Topic     string
+	Partition int32
+	Offset    int64
+	Key       []byte
+	Value     []byte
+	Headers   map[string][]byte
+	Time      time.Time
+}
+
+// Consumer fetches records from a set of topic partitions, typically as a
+// member of a consumer group, and commits the position of the group.
+type Consumer interface {
+	// Fetch blocks until the next record is available or ctx is done.
+	Fetch(ctx context.Context) (Record, error)
+	// Commit commits the offsets of records, marking them and every record
+	// before them in their partitions as consumed.
+	Commit(ctx context.Context, records ...Record) error
+}
+
+// Producer produces records.
+type Producer interface {
+	Produce(ctx context.Context, records ...Record) error
+}
+
+// Message is a Record emitted by FromKafka, to acknowledge once processed.
+// It implements ack.Ackable.
+type Message struct {
+	Record
+	tracker *tracker
+}
+
+// Ack marks the message as processed. Offsets are committed as soon as
+// every earlier message of the same partition has been acknowledged, so a
+// commit never skips over a message still being processed. It returns the
+// commit error, if any.
+func (m *Message) Ack() error {
+	return m.tracker.ack(m.Record)
+}
+
+// Nack marks the message as failed. Its offset, and the offsets after it in
+// its partition, are then never committed by this consumer, so they are
+// redelivered to the group after a restart or a rebalance. FromKafka stops
+// fetching and emits a *NackError, since nothing it emitted afterwards could
+// be committed.
+func (m *Message) Nack(cause error) error {
+	m.tracker.nack(m.Record, cause)
+	return nil
+}
+
+// NackError is emitted by FromKafka once a message has been nacked.
+type NackError struct {
+	Record Record
+	Cause  error
+}
+
+func (e *NackError) Error() string {
+	return fmt.Sprintf("rxkafka: %s/%d at offset %d nacked: %v", e.Record.Topic, e.Record.Partition, e.Record.Offset, e.Cause)
+}
+
+func (e *NackError) Unwrap() error {
+	return e.Cause
+}
+
+type partition struct {
+	topic string
+	id    int32
+}
+
+// tracker commits the offsets of acknowledged records in order.
+type tracker struct {
+	ctx      context.Context
+	consumer Consumer
+	// stop stops the fetching once a message has been nacked.
+	stop context.CancelFunc
+
+	mu sync.Mutex
+	// pending holds, per partition, the offsets fetched and not committed
+	// yet in fetch order, with whether they were acknowledged.
+	pending map[partition][]pendingRecord
+	failed  map[partition]bool
+	nacked  *NackError
+
+	// commitMu serializes the commits, so that concurrent acks can't
+	// commit the offsets of a partition out of order.
+	commitMu  sync.Mutex
+	committed map[partition]int64
+}
+
+type pendingRecord struct {
+	record Record
+	acked  bool
+}
+
+func (t *tracker) fetched(r Record) {
+	t.mu.Lock()
+	p := partition{r.Topic, r.Partition}
+	if !t.failed[p] {
+		t.pending[p] = append(t.pending[p], pendingRecord{record: r})
+	}
+	t.mu.Unlock()
+}
+
+func (t *tracker) ack(r Record) error {
+	t.mu.Lock()
+	p := partition{r.Topic, r.Partition}
+	pending := t.pending[p]
+	i := sort.Search(len(pending), func(i int) bool { return pending[i].record.Offset >= r.Offset })
+	if i < len(pending) && pending[i].record.Offset == r.Offset {
+		pending[i].acked = true
+	}
+	// Commit the last of the leading acknowledged records.
+	var commit *Record
+	n := 0
+	for n < len(pending) && pending[n].acked {
+		commit = &pending[n].record
+		n++
+	}
+	t.pending[p] = pending[n:]
+	t.mu.Unlock()
+
+	if commit == nil {
+		return nil
+	}
+	t.commitMu.Lock()
+	defer t.commitMu.Unlock()
+	if committed, ok := t.committed[p]; ok && committed >= commit.Offset {
+		return nil
+	}
+	if err := t.consumer.Commit(t.ctx, *commit); err != nil {
+		return err
+	}
+	t.committed[p] = commit.Offset
+	return nil
+}
+
+func (t *tracker) nack(r Record, cause error) {
+	t.mu.Lock()
+	p := partition{r.Topic, r.Partition}
+	t.failed[p] = true
+	delete(t.pending, p)
+	if t.nacked == nil {
+		t.nacked = &NackError{Record: r, Cause: cause}
+	}
+	t.mu.Unlock()
+	t.stop()
+}
+
+func (t *tracker) nackError() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.nacked == nil {
+		return nil
+	}
+	return t.nacked
+}
+
+// FromKafka creates an Observable emitting a *Message for each record
+// fetched by consumer, until ctx is done, when it completes, or Fetch fails,
+// when it emits the error. Offsets are only committed as messages are
+// acknowledged, for instance through ack.Process, which gives at-least-once
+// processing. A nacked message stops the Observable with a *NackError.
+func FromKafka(ctx context.Context, consumer Consumer) observable.Observable {
+	fetchCtx, stop := context.WithCancel(ctx)
+	t := &tracker{
+		ctx:       ctx,
+		consumer:  consumer,
+		stop:      stop,
+		pending:   make(map[partition][]pendingRecord),
+		failed:    make(map[partition]bool),
+		committed: make(map[partition]int64),
+	}
+	return observable.Create(func(e observable.Emitter) {
+		defer stop()
+		for {
+			r, err := consumer.Fetch(fetchCtx)
+			if nackErr := t.nackError(); nackErr != nil {
+				e.Error(nackErr)
+				return
+			}
+			if err != nil {
+				if ctx.Err() != nil {
+					e.Complete()
+				} else {
+					e.Error(err)
+				}
+				return
+			}
+			if e.IsDisposed() {
+				return
+			}
+			t.fetched(r)
+			e.Next(&Message{Record: r, tracker: t})
+		}
+	})
+}
+
+// ToKafka produces the items of o with producer: Records as they are, with
+// topic filled in when empty, []byte and strings as the value of a record
+// to topic, and any other item as its JSON encoding. The items of a Batch
+// are produced together. The returned channel receives the Subscription,
+// recording the error of o or the first produce error, which stops the
+// production.
+func ToKafka(ctx context.Context, producer Producer, topic string, o observable.Observable) <-chan subscription.Subscription {
+	return connector.Sink(o, func(item interface{}) error {
+		items := []interface{}{item}
+		if batch, ok := item.(observable.Batch); ok {
+			items = batch
+		}
+		records := make([]Record, 0, len(items))
+		for _, item := range items {
+			r, err := toRecord(topic, item)
+			if err != nil {
+				return err
+			}
+			records = append(records, r)
+		}
+		return producer.Produce(ctx, records...)
+	})
+}
+
+func toRecord(topic string, item interface{}) (Record, error) {
+	if r, ok := item.(Record); ok {
+		if r.Topic == "" {
+			r.Topic = topic
+		}
+		return r, nil
+	}
+	value, err := connector.Payload(item)
+	return Record{Topic: topic, Value: value}, err
+}
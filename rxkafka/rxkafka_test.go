@@ -0,0 +1,151 @@
+package rxkafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/reactivex/rxgo/ack"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeConsumer struct {
+	records chan Record
+	err     error
+
+	mu        sync.Mutex
+	committed []int64
+}
+
+func (c *fakeConsumer) Fetch(ctx context.Context) (Record, error) {
+	select {
+	case r, ok := <-c.records:
+		if !ok {
+			return Record{}, c.err
+		}
+		return r, nil
+	case <-ctx.Done():
+		return Record{}, ctx.Err()
+	}
+}
+
+func (c *fakeConsumer) Commit(ctx context.Context, records ...Record) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, r := range records {
+		c.committed = append(c.committed, r.Offset)
+	}
+	return nil
+}
+
+func newFakeConsumer(offsets ...int64) *fakeConsumer {
+	c := &fakeConsumer{records: make(chan Record, len(offsets))}
+	for _, offset := range offsets {
+		c.records <- Record{Topic: "events", Offset: offset}
+	}
+	return c
+}
+
+func TestFromKafkaCommitsInOrder(t *testing.T) {
+	consumer := newFakeConsumer(10, 11, 12)
+	o := FromKafka(context.Background(), consumer)
+	m10, m11, m12 := (<-o).(*Message), (<-o).(*Message), (<-o).(*Message)
+
+	assert.Nil(t, m11.Ack())
+	assert.Empty(t, consumer.committed)
+	assert.Nil(t, m10.Ack())
+	assert.Equal(t, []int64{11}, consumer.committed)
+	assert.Nil(t, m12.Ack())
+	assert.Equal(t, []int64{11, 12}, consumer.committed)
+}
+
+func TestFromKafkaNack(t *testing.T) {
+	consumer := newFakeConsumer(1, 2, 3)
+	close(consumer.records)
+	consumer.err = errors.New("broker down")
+
+	poison := errors.New("poison")
+	sub := <-ack.Process(FromKafka(context.Background(), consumer), func(item interface{}) error {
+		if item.(*Message).Offset == 2 {
+			return poison
+		}
+		return nil
+	})
+	var nackErr *NackError
+	assert.True(t, errors.As(sub.Err(), &nackErr))
+	assert.Equal(t, int64(2), nackErr.Record.Offset)
+	assert.True(t, errors.Is(sub.Err(), poison))
+	assert.Equal(t, []int64{1}, consumer.committed)
+}
+
+func TestFromKafkaConcurrentAcks(t *testing.T) {
+	const n = 200
+	consumer := newFakeConsumer()
+	tr := &tracker{
+		ctx:       context.Background(),
+		consumer:  consumer,
+		stop:      func() {},
+		pending:   make(map[partition][]pendingRecord),
+		failed:    make(map[partition]bool),
+		committed: make(map[partition]int64),
+	}
+	for offset := int64(0); offset < n; offset++ {
+		tr.fetched(Record{Topic: "events", Offset: offset})
+	}
+	var wg sync.WaitGroup
+	for offset := int64(n - 1); offset >= 0; offset-- {
+		wg.Add(1)
+		go func(offset int64) {
+			defer wg.Done()
+			tr.ack(Record{Topic: "events", Offset: offset})
+		}(offset)
+	}
+	wg.Wait()
+
+	committed := consumer.committed
+	assert.Equal(t, int64(n-1), committed[len(committed)-1])
+	for i := 1; i < len(committed); i++ {
+		if committed[i] <= committed[i-1] {
+			t.Fatalf("offsets committed out of order: %v", committed)
+		}
+	}
+}
+
+func TestFromKafkaCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromKafka(ctx, newFakeConsumer())
+	cancel()
+	_, ok := <-o
+	assert.False(t, ok)
+}
+
+type fakeProducer struct {
+	produced [][]Record
+}
+
+func (p *fakeProducer) Produce(ctx context.Context, records ...Record) error {
+	p.produced = append(p.produced, records)
+	return nil
+}
+
+func TestToKafka(t *testing.T) {
+	producer := new(fakeProducer)
+	sub := <-ToKafka(context.Background(), producer, "out", observable.Just(
+		"text",
+		Record{Key: []byte("k"), Value: []byte("v")},
+		observable.Batch{[]byte{1}, map[string]int{"a": 1}},
+	))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, [][]Record{
+		{{Topic: "out", Value: []byte("text")}},
+		{{Topic: "out", Key: []byte("k"), Value: []byte("v")}},
+		{{Topic: "out", Value: []byte{1}}, {Topic: "out", Value: []byte(`{"a":1}`)}},
+	}, producer.produced)
+
+	sub = <-ToKafka(context.Background(), producer, "out", observable.Just(func() {}))
+	assert.NotNil(t, sub.Err())
+	sub = <-ToKafka(context.Background(), producer, "out", observable.Throw(errors.New("bang")))
+	assert.EqualError(t, sub.Err(), "bang")
+}
@@ -0,0 +1,129 @@
+// Package rxmqtt connects MQTT topics to Observables. Its Client interface
+// takes a few lines to implement over a paho client, so this module doesn't
+// depend on one.
+package rxmqtt
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reactivex/rxgo/internal/connector"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Message is an MQTT message, with the topic it was published on.
+type Message struct {
+	Topic     string
+	QoS       byte
+	Retained  bool
+	Duplicate bool
+	MessageID uint16
+	Payload   []byte
+}
+
+// Client is an MQTT client.
+type Client interface {
+	// Subscribe calls handler with each message published on a topic
+	// matching topicFilter, along with a function acknowledging it to the
+	// broker, which is a no-op for QoS 0. The client must not acknowledge
+	// messages by itself, as with paho's SetAutoAckDisabled.
+	Subscribe(topicFilter string, qos byte, handler func(msg Message, ack func())) error
+	Unsubscribe(topicFilter string) error
+	Publish(msg Message) error
+}
+
+// Delivery is a Message emitted by FromMQTT, to acknowledge once processed.
+// It implements ack.Ackable.
+type Delivery struct {
+	Message
+	once sync.Once
+	ack  func()
+}
+
+// Ack acknowledges the message to the broker, with a PUBACK or a PUBREC
+// depending on its QoS. Only the first call has an effect.
+func (d *Delivery) Ack() error {
+	d.once.Do(d.ack)
+	return nil
+}
+
+// Nack leaves the message unacknowledged, so that a broker keeping the
+// session redelivers it when the client reconnects.
+func (d *Delivery) Nack(cause error) error {
+	return nil
+}
+
+// Option configures FromMQTT.
+type Option func(*config)
+
+type config struct {
+	strategy observable.BackpressureStrategy
+}
+
+// Backpressure sets the BackpressureStrategy applied when the subscriber
+// can't keep up. It defaults to buffering 256 messages and then blocking the
+// client, since dropping a message the broker considers delivered would lose
+// it.
+func Backpressure(strategy observable.BackpressureStrategy) Option {
+	return func(c *config) {
+		c.strategy = strategy
+	}
+}
+
+// FromMQTT creates an Observable emitting a *Delivery for each message
+// published on a topic matching topicFilter, subscribed to with qos, until
+// ctx is done. Failing to subscribe emits the error. Messages are only
+// acknowledged to the broker as Deliveries are, for instance through
+// ack.Process, so QoS 1 and 2 messages aren't acknowledged before being
+// processed.
+func FromMQTT(ctx context.Context, client Client, topicFilter string, qos byte, opts ...Option) observable.Observable {
+	c := config{strategy: observable.Buffer(256)}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	subscribed := make(chan struct{})
+	o := observable.Create(func(e observable.Emitter) {
+		err := client.Subscribe(topicFilter, qos, func(msg Message, ack func()) {
+			e.Next(&Delivery{Message: msg, ack: ack})
+		})
+		close(subscribed)
+		if err != nil {
+			e.Error(err)
+			return
+		}
+
+		<-ctx.Done()
+		// Complete first, which releases a handler blocked by a slow
+		// subscriber, as the client may wait for its handlers to
+		// unsubscribe.
+		e.Complete()
+		client.Unsubscribe(topicFilter)
+	})
+	// Subscribe before returning, so that no message published from now on
+	// is missed.
+	<-subscribed
+	return o.OnBackpressure(c.strategy)
+}
+
+// ToMQTT publishes the items of o on topic with qos: Messages as they are,
+// with topic filled in when empty, []byte and strings as the payload of a
+// message, and any other item as its JSON encoding. The returned channel
+// receives the Subscription, recording the error of o or the first publish
+// error, which stops the publishing.
+func ToMQTT(client Client, topic string, qos byte, o observable.Observable) <-chan subscription.Subscription {
+	return connector.Sink(o, func(item interface{}) error {
+		if msg, ok := item.(Message); ok {
+			if msg.Topic == "" {
+				msg.Topic = topic
+			}
+			return client.Publish(msg)
+		}
+		payload, err := connector.Payload(item)
+		if err != nil {
+			return err
+		}
+		return client.Publish(Message{Topic: topic, QoS: qos, Payload: payload})
+	})
+}
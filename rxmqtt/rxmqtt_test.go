@@ -0,0 +1,160 @@
+package rxmqtt
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/ack"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClient struct {
+	mu        sync.Mutex
+	handlers  map[string]func(Message, func())
+	acked     []uint16
+	published []Message
+	err       error
+}
+
+func newFakeClient() *fakeClient {
+	return &fakeClient{handlers: make(map[string]func(Message, func()))}
+}
+
+func (c *fakeClient) Subscribe(topicFilter string, qos byte, handler func(Message, func())) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.handlers[topicFilter] = handler
+	return nil
+}
+
+func (c *fakeClient) Unsubscribe(topicFilter string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	delete(c.handlers, topicFilter)
+	return nil
+}
+
+func (c *fakeClient) Publish(msg Message) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.published = append(c.published, msg)
+	return nil
+}
+
+func (c *fakeClient) deliver(topicFilter string, msg Message) {
+	c.mu.Lock()
+	handler := c.handlers[topicFilter]
+	c.mu.Unlock()
+	handler(msg, func() {
+		c.mu.Lock()
+		defer c.mu.Unlock()
+		c.acked = append(c.acked, msg.MessageID)
+	})
+}
+
+func (c *fakeClient) waitUnsubscribed(t *testing.T, topicFilter string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		_, subscribed := c.handlers[topicFilter]
+		c.mu.Unlock()
+		if !subscribed {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s still subscribed", topicFilter)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFromMQTT(t *testing.T) {
+	client := newFakeClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromMQTT(ctx, client, "sensors/+/temperature", 1)
+
+	client.deliver("sensors/+/temperature", Message{Topic: "sensors/a/temperature", QoS: 1, MessageID: 1, Payload: []byte("21.5")})
+	client.deliver("sensors/+/temperature", Message{Topic: "sensors/b/temperature", QoS: 1, MessageID: 2, Payload: []byte("bad")})
+	client.deliver("sensors/+/temperature", Message{Topic: "sensors/c/temperature", QoS: 1, MessageID: 3, Payload: []byte("19")})
+	cancel()
+
+	var topics []string
+	sub := <-ack.Process(o, func(item interface{}) error {
+		d := item.(*Delivery)
+		topics = append(topics, d.Topic)
+		if string(d.Payload) == "bad" {
+			return errors.New("unparsable reading")
+		}
+		return nil
+	})
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []string{"sensors/a/temperature", "sensors/b/temperature", "sensors/c/temperature"}, topics)
+	assert.Equal(t, []uint16{1, 3}, client.acked)
+	client.waitUnsubscribed(t, "sensors/+/temperature")
+}
+
+func TestFromMQTTCancelWhileBlocked(t *testing.T) {
+	client := newFakeClient()
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromMQTT(ctx, client, "sensors/#", 1, Backpressure(observable.Buffer(1)))
+
+	delivered := make(chan struct{})
+	go func() {
+		for id := uint16(1); id <= 3; id++ {
+			client.deliver("sensors/#", Message{Topic: "sensors/a", MessageID: id})
+		}
+		close(delivered)
+	}()
+	// With nothing reading, the handler blocks on the full buffer until
+	// the cancellation releases it.
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+	<-delivered
+	client.waitUnsubscribed(t, "sensors/#")
+
+	for range o {
+	}
+}
+
+func TestDeliveryAckOnce(t *testing.T) {
+	acks := 0
+	d := &Delivery{ack: func() { acks++ }}
+	assert.Nil(t, d.Ack())
+	assert.Nil(t, d.Ack())
+	assert.Equal(t, 1, acks)
+}
+
+func TestFromMQTTSubscribeError(t *testing.T) {
+	client := newFakeClient()
+	client.err = errors.New("not connected")
+	items, err := FromMQTT(context.Background(), client, "sensors/#", 0).ToSlice()
+	assert.Empty(t, items)
+	assert.EqualError(t, err, "not connected")
+}
+
+func TestToMQTT(t *testing.T) {
+	client := newFakeClient()
+	sub := <-ToMQTT(client, "commands", 1, observable.Just(
+		"on", Message{Topic: "alerts", QoS: 2, Retained: true, Payload: []byte("hot")}, map[string]int{"level": 3},
+	))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []Message{
+		{Topic: "commands", QoS: 1, Payload: []byte("on")},
+		{Topic: "alerts", QoS: 2, Retained: true, Payload: []byte("hot")},
+		{Topic: "commands", QoS: 1, Payload: []byte(`{"level":3}`)},
+	}, client.published)
+
+	client.err = errors.New("not connected")
+	sub = <-ToMQTT(client, "commands", 1, observable.Just("off"))
+	assert.EqualError(t, sub.Err(), "not connected")
+}
@@ -0,0 +1,203 @@
+// Package rxnats connects NATS subjects to Observables. Its Conn interface
+// takes a few lines to implement over a *nats.Conn, so this module doesn't
+// depend on the NATS client.
+package rxnats
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reactivex/rxgo/internal/connector"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Msg is a NATS message.
+type Msg struct {
+	Subject string
+	Reply   string
+	Data    []byte
+	Header  map[string][]string
+}
+
+// Subscription is an active subscription to a subject.
+type Subscription interface {
+	Unsubscribe() error
+}
+
+// Conn is a NATS connection.
+type Conn interface {
+	// Subscribe calls handler with each message published on subject. With
+	// a non-empty queue, the subscription joins that queue group, and each
+	// message is delivered to a single member of the group.
+	Subscribe(subject, queue string, handler func(Msg)) (Subscription, error)
+	Publish(msg Msg) error
+}
+
+// ConnectionEventKind is the kind of a ConnectionEvent.
+type ConnectionEventKind uint32
+
+const (
+	// Disconnected is reported when the connection is lost.
+	Disconnected ConnectionEventKind = iota
+	// Reconnected is reported when the connection is back.
+	Reconnected
+)
+
+// ConnectionEvent is emitted by FromNATS, among the messages, when the
+// state of the connection changes.
+type ConnectionEvent struct {
+	Kind ConnectionEventKind
+	Err  error
+}
+
+// Status relays the state of a connection to the Observables created by
+// FromNATS with WithStatus. Call its methods from the handlers of the
+// connection, such as nats.DisconnectErrHandler, nats.ReconnectHandler and
+// nats.ClosedHandler.
+type Status struct {
+	mu        sync.Mutex
+	listeners map[observable.Emitter]struct{}
+	once      sync.Once
+	closed    chan struct{}
+}
+
+// NewStatus creates a Status.
+func NewStatus() *Status {
+	return &Status{listeners: make(map[observable.Emitter]struct{}), closed: make(chan struct{})}
+}
+
+func (s *Status) add(e observable.Emitter) {
+	s.mu.Lock()
+	s.listeners[e] = struct{}{}
+	s.mu.Unlock()
+}
+
+func (s *Status) remove(e observable.Emitter) {
+	s.mu.Lock()
+	delete(s.listeners, e)
+	s.mu.Unlock()
+}
+
+func (s *Status) emit(event ConnectionEvent) {
+	s.mu.Lock()
+	listeners := make([]observable.Emitter, 0, len(s.listeners))
+	for e := range s.listeners {
+		listeners = append(listeners, e)
+	}
+	s.mu.Unlock()
+	for _, e := range listeners {
+		e.Next(event)
+	}
+}
+
+// Disconnected emits a Disconnected ConnectionEvent carrying err.
+func (s *Status) Disconnected(err error) {
+	s.emit(ConnectionEvent{Kind: Disconnected, Err: err})
+}
+
+// Reconnected emits a Reconnected ConnectionEvent.
+func (s *Status) Reconnected() {
+	s.emit(ConnectionEvent{Kind: Reconnected})
+}
+
+// Closed completes the Observables, as the connection won't come back.
+func (s *Status) Closed() {
+	s.once.Do(func() { close(s.closed) })
+}
+
+// Option configures FromNATS.
+type Option func(*config)
+
+type config struct {
+	queue    string
+	status   *Status
+	strategy observable.BackpressureStrategy
+}
+
+// Queue makes FromNATS join the queue group called queue.
+func Queue(queue string) Option {
+	return func(c *config) {
+		c.queue = queue
+	}
+}
+
+// WithStatus makes FromNATS emit the ConnectionEvents relayed by status,
+// and complete when it reports the connection closed.
+func WithStatus(status *Status) Option {
+	return func(c *config) {
+		c.status = status
+	}
+}
+
+// Backpressure sets the BackpressureStrategy applied when the subscriber
+// can't keep up. It defaults to buffering 256 messages and dropping new ones
+// beyond, so that the connection is never blocked.
+func Backpressure(strategy observable.BackpressureStrategy) Option {
+	return func(c *config) {
+		c.strategy = strategy
+	}
+}
+
+// FromNATS creates an Observable emitting a Msg for each message published
+// on subject, which may contain wildcards, until ctx is done. Failing to
+// subscribe emits the error. Problems of the connection don't terminate the
+// Observable: with WithStatus they are emitted as ConnectionEvents.
+func FromNATS(ctx context.Context, conn Conn, subject string, opts ...Option) observable.Observable {
+	c := config{strategy: observable.BackpressureStrategy{Capacity: 256, Overflow: observable.OverflowDropLatest}}
+	for _, opt := range opts {
+		opt(&c)
+	}
+
+	var closed <-chan struct{}
+	if c.status != nil {
+		closed = c.status.closed
+	}
+	subscribed := make(chan struct{})
+	o := observable.Create(func(e observable.Emitter) {
+		sub, err := conn.Subscribe(subject, c.queue, func(m Msg) { e.Next(m) })
+		if err == nil && c.status != nil {
+			c.status.add(e)
+		}
+		close(subscribed)
+		if err != nil {
+			e.Error(err)
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-closed:
+		}
+		e.Complete()
+		if c.status != nil {
+			c.status.remove(e)
+		}
+		sub.Unsubscribe()
+	})
+	// Subscribe before returning, so that no message published from now on
+	// is missed.
+	<-subscribed
+	return o.OnBackpressure(c.strategy)
+}
+
+// ToNATS publishes the items of o on conn: Msgs as they are, with subject
+// filled in when empty, []byte and strings as the data of a message to
+// subject, and any other item as its JSON encoding. The returned channel
+// receives the Subscription, recording the error of o or the first publish
+// error, which stops the publishing.
+func ToNATS(conn Conn, subject string, o observable.Observable) <-chan subscription.Subscription {
+	return connector.Sink(o, func(item interface{}) error {
+		if msg, ok := item.(Msg); ok {
+			if msg.Subject == "" {
+				msg.Subject = subject
+			}
+			return conn.Publish(msg)
+		}
+		data, err := connector.Payload(item)
+		if err != nil {
+			return err
+		}
+		return conn.Publish(Msg{Subject: subject, Data: data})
+	})
+}
@@ -0,0 +1,149 @@
+package rxnats
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeSubscription struct {
+	conn    *fakeConn
+	subject string
+}
+
+func (s *fakeSubscription) Unsubscribe() error {
+	s.conn.mu.Lock()
+	defer s.conn.mu.Unlock()
+	delete(s.conn.handlers, s.subject)
+	return nil
+}
+
+type fakeConn struct {
+	mu        sync.Mutex
+	handlers  map[string]func(Msg)
+	queues    map[string]string
+	published []Msg
+	err       error
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{handlers: make(map[string]func(Msg)), queues: make(map[string]string)}
+}
+
+func (c *fakeConn) Subscribe(subject, queue string, handler func(Msg)) (Subscription, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return nil, c.err
+	}
+	c.handlers[subject] = handler
+	c.queues[subject] = queue
+	return &fakeSubscription{conn: c, subject: subject}, nil
+}
+
+func (c *fakeConn) Publish(msg Msg) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.err != nil {
+		return c.err
+	}
+	c.published = append(c.published, msg)
+	return nil
+}
+
+func (c *fakeConn) deliver(msg Msg) {
+	c.mu.Lock()
+	handler := c.handlers[msg.Subject]
+	c.mu.Unlock()
+	if handler != nil {
+		handler(msg)
+	}
+}
+
+func (c *fakeConn) waitUnsubscribed(t *testing.T, subject string) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		c.mu.Lock()
+		_, subscribed := c.handlers[subject]
+		c.mu.Unlock()
+		if !subscribed {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%s still subscribed", subject)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestFromNATS(t *testing.T) {
+	conn := newFakeConn()
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromNATS(ctx, conn, "orders", Queue("workers"))
+	assert.Equal(t, "workers", conn.queues["orders"])
+
+	conn.deliver(Msg{Subject: "orders", Data: []byte("a")})
+	conn.deliver(Msg{Subject: "orders", Data: []byte("b")})
+	assert.Equal(t, Msg{Subject: "orders", Data: []byte("a")}, <-o)
+	assert.Equal(t, Msg{Subject: "orders", Data: []byte("b")}, <-o)
+
+	cancel()
+	_, ok := <-o
+	assert.False(t, ok)
+	conn.waitUnsubscribed(t, "orders")
+}
+
+func TestFromNATSSubscribeError(t *testing.T) {
+	conn := newFakeConn()
+	conn.err = errors.New("nats: connection closed")
+	items, err := FromNATS(context.Background(), conn, "orders").ToSlice()
+	assert.Empty(t, items)
+	assert.EqualError(t, err, "nats: connection closed")
+}
+
+func TestFromNATSStatus(t *testing.T) {
+	conn := newFakeConn()
+	status := NewStatus()
+	o := FromNATS(context.Background(), conn, "orders", WithStatus(status))
+
+	lost := errors.New("EOF")
+	status.Disconnected(lost)
+	status.Reconnected()
+	conn.deliver(Msg{Subject: "orders", Data: []byte("a")})
+	status.Closed()
+
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		ConnectionEvent{Kind: Disconnected, Err: lost},
+		ConnectionEvent{Kind: Reconnected},
+		Msg{Subject: "orders", Data: []byte("a")},
+	}, items)
+}
+
+func TestToNATS(t *testing.T) {
+	conn := newFakeConn()
+	sub := <-ToNATS(conn, "orders", observable.Just(
+		"a", []byte("b"), Msg{Subject: "audit", Data: []byte("c")}, Msg{Data: []byte("d")}, map[string]int{"e": 1},
+	))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []Msg{
+		{Subject: "orders", Data: []byte("a")},
+		{Subject: "orders", Data: []byte("b")},
+		{Subject: "audit", Data: []byte("c")},
+		{Subject: "orders", Data: []byte("d")},
+		{Subject: "orders", Data: []byte(`{"e":1}`)},
+	}, conn.published)
+}
+
+func TestToNATSError(t *testing.T) {
+	conn := newFakeConn()
+	conn.err = errors.New("nats: connection closed")
+	sub := <-ToNATS(conn, "orders", observable.Just("a", "b"))
+	assert.EqualError(t, sub.Err(), "nats: connection closed")
+}
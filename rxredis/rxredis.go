@@ -0,0 +1,70 @@
+// Package rxredis connects Redis Pub/Sub channels and Streams to
+// Observables. Its interfaces mirror the few commands it needs and take a
+// few lines to implement over any Redis client, so this module doesn't
+// depend on one.
+package rxredis
+
+import (
+	"context"
+
+	"github.com/reactivex/rxgo/internal/connector"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Message is a message received on a Pub/Sub channel. Pattern is the
+// pattern the channel matched, for pattern subscriptions.
+type Message struct {
+	Channel string
+	Pattern string
+	Payload string
+}
+
+// PubSub is a subscription to a set of Pub/Sub channels or patterns.
+type PubSub interface {
+	// ReceiveMessage blocks until the next message or until ctx is done.
+	ReceiveMessage(ctx context.Context) (Message, error)
+}
+
+// Publisher publishes messages to Pub/Sub channels.
+type Publisher interface {
+	Publish(ctx context.Context, channel string, payload []byte) error
+}
+
+// FromPubSub creates an Observable emitting each Message received by ps,
+// until ctx is done, when it completes, or ReceiveMessage fails, when it
+// emits the error. Pub/Sub delivers at most once: messages published while
+// ps is disconnected are lost.
+func FromPubSub(ctx context.Context, ps PubSub) observable.Observable {
+	return observable.Create(func(e observable.Emitter) {
+		for {
+			m, err := ps.ReceiveMessage(ctx)
+			if err != nil {
+				if ctx.Err() != nil {
+					e.Complete()
+				} else {
+					e.Error(err)
+				}
+				return
+			}
+			if e.IsDisposed() {
+				return
+			}
+			e.Next(m)
+		}
+	})
+}
+
+// ToPubSub publishes the items of o to channel: []byte and strings as they
+// are, and any other item as its JSON encoding. The returned channel
+// receives the Subscription, recording the error of o or the first publish
+// error, which stops the publishing.
+func ToPubSub(ctx context.Context, publisher Publisher, channel string, o observable.Observable) <-chan subscription.Subscription {
+	return connector.Sink(o, func(item interface{}) error {
+		payload, err := connector.Payload(item)
+		if err != nil {
+			return err
+		}
+		return publisher.Publish(ctx, channel, payload)
+	})
+}
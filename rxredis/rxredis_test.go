@@ -0,0 +1,79 @@
+package rxredis
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakePubSub struct {
+	messages chan Message
+	err      error
+}
+
+func (ps *fakePubSub) ReceiveMessage(ctx context.Context) (Message, error) {
+	select {
+	case m, ok := <-ps.messages:
+		if !ok {
+			return Message{}, ps.err
+		}
+		return m, nil
+	case <-ctx.Done():
+		return Message{}, ctx.Err()
+	}
+}
+
+type published struct {
+	channel string
+	payload string
+}
+
+type fakePublisher struct {
+	published []published
+	err       error
+}
+
+func (p *fakePublisher) Publish(ctx context.Context, channel string, payload []byte) error {
+	if p.err != nil {
+		return p.err
+	}
+	p.published = append(p.published, published{channel, string(payload)})
+	return nil
+}
+
+func TestFromPubSub(t *testing.T) {
+	ps := &fakePubSub{messages: make(chan Message, 2), err: errors.New("connection reset")}
+	ps.messages <- Message{Channel: "prices.eu", Pattern: "prices.*", Payload: "1"}
+	ps.messages <- Message{Channel: "prices.us", Pattern: "prices.*", Payload: "2"}
+	close(ps.messages)
+
+	items, err := FromPubSub(context.Background(), ps).ToSlice()
+	assert.EqualError(t, err, "connection reset")
+	assert.Equal(t, []interface{}{
+		Message{Channel: "prices.eu", Pattern: "prices.*", Payload: "1"},
+		Message{Channel: "prices.us", Pattern: "prices.*", Payload: "2"},
+	}, items)
+}
+
+func TestFromPubSubCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromPubSub(ctx, &fakePubSub{messages: make(chan Message)})
+	cancel()
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestToPubSub(t *testing.T) {
+	p := &fakePublisher{}
+	sub := <-ToPubSub(context.Background(), p, "prices", observable.Just("a", []byte("b"), 3))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []published{{"prices", "a"}, {"prices", "b"}, {"prices", "3"}}, p.published)
+
+	p = &fakePublisher{err: errors.New("READONLY")}
+	sub = <-ToPubSub(context.Background(), p, "prices", observable.Just("a"))
+	assert.EqualError(t, sub.Err(), "READONLY")
+}
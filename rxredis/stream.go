@@ -0,0 +1,120 @@
+package rxredis
+
+import (
+	"context"
+
+	"github.com/reactivex/rxgo/internal/connector"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// DataField is the field under which ToStream stores items which aren't
+// field maps.
+const DataField = "data"
+
+// Entry is an entry of a Redis Stream.
+type Entry struct {
+	Stream string
+	ID     string
+	Values map[string]interface{}
+}
+
+// GroupReader reads Streams as a consumer of a consumer group.
+type GroupReader interface {
+	// ReadGroup reads entries never delivered to the group from streams,
+	// like XREADGROUP GROUP group consumer STREAMS streams... >, blocking
+	// until at least one is available or ctx is done.
+	ReadGroup(ctx context.Context, group, consumer string, streams ...string) ([]Entry, error)
+	// ReadPending reads the entries of streams delivered to consumer and
+	// not acknowledged yet, like XREADGROUP GROUP group consumer STREAMS
+	// streams... 0, without blocking.
+	ReadPending(ctx context.Context, group, consumer string, streams ...string) ([]Entry, error)
+	// Ack acknowledges entries of stream, removing them from the pending
+	// entries list of group, like XACK.
+	Ack(ctx context.Context, stream, group string, ids ...string) error
+}
+
+// StreamWriter appends entries to Streams, like XADD with an automatic ID.
+type StreamWriter interface {
+	Add(ctx context.Context, stream string, values map[string]interface{}) (id string, err error)
+}
+
+// StreamMessage is an Entry emitted by FromStream, to acknowledge once
+// processed. It implements ack.Ackable.
+type StreamMessage struct {
+	Entry
+	ctx    context.Context
+	reader GroupReader
+	group  string
+}
+
+// Ack acknowledges the entry with XACK.
+func (m *StreamMessage) Ack() error {
+	return m.reader.Ack(m.ctx, m.Stream, m.group, m.ID)
+}
+
+// Nack leaves the entry in the pending entries list of the group, from where
+// FromStream reads it again the next time it starts for the same consumer,
+// unless another consumer claims it first with XCLAIM.
+func (m *StreamMessage) Nack(cause error) error {
+	return nil
+}
+
+// FromStream creates an Observable emitting a *StreamMessage for each entry
+// read from streams by consumer as a member of group, until ctx is done,
+// when it completes, or reading fails, when it emits the error. It starts
+// with the entries still pending for consumer, delivered before a restart
+// or nacked, then reads new ones. Entries are only acknowledged as messages
+// are, for instance through ack.Process, which gives at-least-once
+// processing.
+func FromStream(ctx context.Context, reader GroupReader, group, consumer string, streams ...string) observable.Observable {
+	return observable.Create(func(e observable.Emitter) {
+		read := reader.ReadPending
+		for {
+			entries, err := read(ctx, group, consumer, streams...)
+			read = reader.ReadGroup
+			if err != nil {
+				if ctx.Err() != nil {
+					e.Complete()
+				} else {
+					e.Error(err)
+				}
+				return
+			}
+			for _, entry := range entries {
+				if e.IsDisposed() {
+					return
+				}
+				e.Next(&StreamMessage{Entry: entry, ctx: ctx, reader: reader, group: group})
+			}
+		}
+	})
+}
+
+// ToStream appends the items of o to stream: Entries to their own stream,
+// or stream when empty, field maps as they are, and any other item under
+// DataField, as is for []byte and strings, or as its JSON encoding. The
+// returned channel receives the Subscription, recording the error of o or
+// the first write error, which stops the writing.
+func ToStream(ctx context.Context, writer StreamWriter, stream string, o observable.Observable) <-chan subscription.Subscription {
+	return connector.Sink(o, func(item interface{}) error {
+		target, values := stream, map[string]interface{}(nil)
+		switch item := item.(type) {
+		case Entry:
+			values = item.Values
+			if item.Stream != "" {
+				target = item.Stream
+			}
+		case map[string]interface{}:
+			values = item
+		default:
+			data, err := connector.Payload(item)
+			if err != nil {
+				return err
+			}
+			values = map[string]interface{}{DataField: data}
+		}
+		_, err := writer.Add(ctx, target, values)
+		return err
+	})
+}
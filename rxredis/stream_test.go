@@ -0,0 +1,99 @@
+package rxredis
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/reactivex/rxgo/ack"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeGroupReader struct {
+	pending []Entry
+	batches chan []Entry
+	err     error
+
+	mu    sync.Mutex
+	acked []string
+}
+
+func (r *fakeGroupReader) ReadGroup(ctx context.Context, group, consumer string, streams ...string) ([]Entry, error) {
+	select {
+	case entries, ok := <-r.batches:
+		if !ok {
+			return nil, r.err
+		}
+		return entries, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (r *fakeGroupReader) ReadPending(ctx context.Context, group, consumer string, streams ...string) ([]Entry, error) {
+	return r.pending, nil
+}
+
+func (r *fakeGroupReader) Ack(ctx context.Context, stream, group string, ids ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, id := range ids {
+		r.acked = append(r.acked, group+":"+stream+":"+id)
+	}
+	return nil
+}
+
+type fakeStreamWriter struct {
+	added []Entry
+}
+
+func (w *fakeStreamWriter) Add(ctx context.Context, stream string, values map[string]interface{}) (string, error) {
+	w.added = append(w.added, Entry{Stream: stream, Values: values})
+	return "0-1", nil
+}
+
+func TestFromStream(t *testing.T) {
+	r := &fakeGroupReader{batches: make(chan []Entry, 2), err: errors.New("NOGROUP")}
+	r.pending = []Entry{{Stream: "orders", ID: "0-1"}}
+	r.batches <- []Entry{{Stream: "orders", ID: "1-0"}, {Stream: "orders", ID: "2-0"}}
+	r.batches <- []Entry{{Stream: "orders", ID: "3-0"}}
+	close(r.batches)
+
+	o := FromStream(context.Background(), r, "billing", "worker-1", "orders")
+	sub := <-ack.Process(o, func(item interface{}) error {
+		if item.(*StreamMessage).ID == "2-0" {
+			return errors.New("poison")
+		}
+		return nil
+	})
+	assert.EqualError(t, sub.Err(), "NOGROUP")
+	assert.Equal(t, []string{"billing:orders:0-1", "billing:orders:1-0", "billing:orders:3-0"}, r.acked)
+}
+
+func TestFromStreamCancel(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	o := FromStream(ctx, &fakeGroupReader{batches: make(chan []Entry)}, "billing", "worker-1", "orders")
+	cancel()
+	items, err := o.ToSlice()
+	assert.Nil(t, err)
+	assert.Empty(t, items)
+}
+
+func TestToStream(t *testing.T) {
+	w := &fakeStreamWriter{}
+	sub := <-ToStream(context.Background(), w, "orders", observable.Just(
+		"a",
+		map[string]interface{}{"sku": "b"},
+		Entry{Stream: "audit", Values: map[string]interface{}{"c": 1}},
+		[]int{4},
+	))
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []Entry{
+		{Stream: "orders", Values: map[string]interface{}{DataField: []byte("a")}},
+		{Stream: "orders", Values: map[string]interface{}{"sku": "b"}},
+		{Stream: "audit", Values: map[string]interface{}{"c": 1}},
+		{Stream: "orders", Values: map[string]interface{}{DataField: []byte("[4]")}},
+	}, w.added)
+}
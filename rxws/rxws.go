@@ -0,0 +1,242 @@
+// Package rxws bridges WebSocket connections and Observables: inbound
+// messages as a source, and an Observable as the outbound frames.
+//
+// Conn is the subset of the *websocket.Conn of github.com/gorilla/websocket
+// the bridge needs, so such connections can be passed as they are.
+package rxws
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Message types, as defined by RFC 6455 and gorilla/websocket.
+const (
+	TextMessage   = 1
+	BinaryMessage = 2
+	CloseMessage  = 8
+	PingMessage   = 9
+	PongMessage   = 10
+)
+
+// Close codes used by the bridge, as defined by RFC 6455.
+const (
+	CloseNormalClosure = 1000
+	CloseGoingAway     = 1001
+	CloseInternalError = 1011
+)
+
+// Conn is a WebSocket connection. Close and WriteControl may be called
+// concurrently with the other methods.
+type Conn interface {
+	ReadMessage() (messageType int, data []byte, err error)
+	WriteMessage(messageType int, data []byte) error
+	WriteControl(messageType int, data []byte, deadline time.Time) error
+	SetReadDeadline(t time.Time) error
+	SetPongHandler(h func(appData string) error)
+	Close() error
+}
+
+// Message is a WebSocket message.
+type Message struct {
+	Type int
+	Data []byte
+}
+
+// Option configures FromWebSocket and ToWebSocket.
+type Option func(*config)
+
+type config struct {
+	pingPeriod   time.Duration
+	pongWait     time.Duration
+	writeTimeout time.Duration
+	closeCode    func(error) (int, bool)
+}
+
+// Keepalive makes FromWebSocket send a ping every pingPeriod and fail if
+// nothing, pong or message, arrives within pongWait. It defaults to a ping
+// every 54 seconds within a 60 seconds wait; a zero pingPeriod disables
+// keepalive.
+func Keepalive(pingPeriod, pongWait time.Duration) Option {
+	return func(c *config) {
+		c.pingPeriod, c.pongWait = pingPeriod, pongWait
+	}
+}
+
+// WriteTimeout bounds how long control frames may take to be written. It
+// defaults to 10 seconds.
+func WriteTimeout(d time.Duration) Option {
+	return func(c *config) {
+		c.writeTimeout = d
+	}
+}
+
+// CloseCode sets how FromWebSocket extracts the close code from the error
+// returned by ReadMessage once the peer closed the connection. By default
+// it parses the errors of gorilla/websocket.
+func CloseCode(closeCode func(error) (code int, ok bool)) Option {
+	return func(c *config) {
+		c.closeCode = closeCode
+	}
+}
+
+func newConfig(opts []Option) config {
+	c := config{
+		pingPeriod:   54 * time.Second,
+		pongWait:     60 * time.Second,
+		writeTimeout: 10 * time.Second,
+		closeCode:    gorillaCloseCode,
+	}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	return c
+}
+
+// gorillaCloseCode parses the close code of a gorilla/websocket CloseError,
+// whose message reads "websocket: close 1000 (normal)".
+func gorillaCloseCode(err error) (int, bool) {
+	msg := strings.TrimPrefix(err.Error(), "websocket: close ")
+	if msg == err.Error() {
+		return 0, false
+	}
+	if i := strings.IndexByte(msg, ' '); i >= 0 {
+		msg = msg[:i]
+	}
+	code, convErr := strconv.Atoi(msg)
+	return code, convErr == nil
+}
+
+// FromWebSocket creates an Observable emitting each Message received on
+// conn. It completes when the peer closes the connection normally, or
+// going away, and fails with the read error otherwise, including when the
+// keepalive times out.
+func FromWebSocket(conn Conn, opts ...Option) observable.Observable {
+	c := newConfig(opts)
+	return observable.Create(func(e observable.Emitter) {
+		stop := make(chan struct{})
+		defer close(stop)
+		if c.pingPeriod > 0 {
+			conn.SetReadDeadline(time.Now().Add(c.pongWait))
+			conn.SetPongHandler(func(string) error {
+				return conn.SetReadDeadline(time.Now().Add(c.pongWait))
+			})
+			go ping(conn, c, stop)
+		}
+
+		for {
+			messageType, data, err := conn.ReadMessage()
+			if err != nil {
+				if code, ok := c.closeCode(err); ok && (code == CloseNormalClosure || code == CloseGoingAway) {
+					e.Complete()
+				} else {
+					e.Error(err)
+				}
+				return
+			}
+			if c.pingPeriod > 0 {
+				conn.SetReadDeadline(time.Now().Add(c.pongWait))
+			}
+			if e.IsDisposed() {
+				return
+			}
+			e.Next(Message{Type: messageType, Data: data})
+		}
+	})
+}
+
+// ping sends pings on conn every pingPeriod until stop is closed.
+func ping(conn Conn, c config, stop <-chan struct{}) {
+	ticker := time.NewTicker(c.pingPeriod)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+			if err := conn.WriteControl(PingMessage, nil, time.Now().Add(c.writeTimeout)); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// maxCloseReason is the longest reason a close frame can carry: control
+// frames are limited to 125 bytes, 2 of which hold the code.
+const maxCloseReason = 123
+
+// closeMessage formats the payload of a close frame, truncating text to
+// maxCloseReason bytes without splitting a UTF-8 sequence.
+func closeMessage(code int, text string) []byte {
+	if len(text) > maxCloseReason {
+		n := maxCloseReason
+		for n > 0 && !utf8.RuneStart(text[n]) {
+			n--
+		}
+		text = text[:n]
+	}
+	buf := make([]byte, 2+len(text))
+	binary.BigEndian.PutUint16(buf, uint16(code))
+	copy(buf[2:], text)
+	return buf
+}
+
+// ToWebSocket writes the items of o to conn as they are emitted: Messages
+// as they are, []byte as binary messages, strings as text messages and any
+// other item as JSON text. Once o completes it sends a normal close frame,
+// and if o fails it sends an internal error close frame carrying the error
+// message, truncated to fit the frame. The returned channel receives the
+// Subscription, recording the error of o or the first write error, which
+// stops the writing.
+func ToWebSocket(conn Conn, o observable.Observable, opts ...Option) <-chan subscription.Subscription {
+	c := newConfig(opts)
+	done := make(chan subscription.Subscription, 1)
+	go func() {
+		sub := subscription.New().Subscribe()
+		closing := closeMessage(CloseNormalClosure, "")
+		for item := range o {
+			if err, isErr := item.(error); isErr {
+				sub.Error = err
+				closing = closeMessage(CloseInternalError, err.Error())
+				break
+			}
+			if err := writeItem(conn, item); err != nil {
+				sub.Error = err
+				closing = nil
+				break
+			}
+		}
+		if closing != nil {
+			err := conn.WriteControl(CloseMessage, closing, time.Now().Add(c.writeTimeout))
+			if sub.Error == nil {
+				sub.Error = err
+			}
+		}
+		done <- sub.Unsubscribe()
+	}()
+	return done
+}
+
+func writeItem(conn Conn, item interface{}) error {
+	switch item := item.(type) {
+	case Message:
+		return conn.WriteMessage(item.Type, item.Data)
+	case []byte:
+		return conn.WriteMessage(BinaryMessage, item)
+	case string:
+		return conn.WriteMessage(TextMessage, []byte(item))
+	default:
+		data, err := json.Marshal(item)
+		if err != nil {
+			return err
+		}
+		return conn.WriteMessage(TextMessage, data)
+	}
+}
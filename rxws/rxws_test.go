@@ -0,0 +1,158 @@
+package rxws
+
+import (
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+// fakeConn is an in-memory Conn. Inbound messages are sent on in, and a
+// read error ends them.
+type fakeConn struct {
+	in      chan Message
+	readErr error
+
+	mu       sync.Mutex
+	written  []Message
+	control  []Message
+	deadline time.Time
+	onPong   func(string) error
+}
+
+func newFakeConn() *fakeConn {
+	return &fakeConn{in: make(chan Message, 8)}
+}
+
+func (c *fakeConn) ReadMessage() (int, []byte, error) {
+	m, ok := <-c.in
+	if !ok {
+		return 0, nil, c.readErr
+	}
+	return m.Type, m.Data, nil
+}
+
+func (c *fakeConn) WriteMessage(messageType int, data []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if messageType == -1 {
+		return errors.New("broken pipe")
+	}
+	c.written = append(c.written, Message{Type: messageType, Data: data})
+	return nil
+}
+
+func (c *fakeConn) WriteControl(messageType int, data []byte, deadline time.Time) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.control = append(c.control, Message{Type: messageType, Data: data})
+	if messageType == PingMessage && c.onPong != nil {
+		go c.onPong("")
+	}
+	return nil
+}
+
+func (c *fakeConn) SetReadDeadline(t time.Time) error {
+	c.mu.Lock()
+	c.deadline = t
+	c.mu.Unlock()
+	return nil
+}
+
+func (c *fakeConn) SetPongHandler(h func(string) error) {
+	c.mu.Lock()
+	c.onPong = h
+	c.mu.Unlock()
+}
+
+func (c *fakeConn) Close() error {
+	return nil
+}
+
+func (c *fakeConn) controls() []Message {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return append([]Message(nil), c.control...)
+}
+
+func TestFromWebSocket(t *testing.T) {
+	conn := newFakeConn()
+	conn.in <- Message{Type: TextMessage, Data: []byte("hello")}
+	conn.in <- Message{Type: BinaryMessage, Data: []byte{1, 2}}
+	conn.readErr = errors.New("websocket: close 1000 (normal)")
+	close(conn.in)
+
+	items, err := FromWebSocket(conn).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{
+		Message{Type: TextMessage, Data: []byte("hello")},
+		Message{Type: BinaryMessage, Data: []byte{1, 2}},
+	}, items)
+}
+
+func TestFromWebSocketAbnormalClose(t *testing.T) {
+	conn := newFakeConn()
+	conn.readErr = errors.New("websocket: close 1006 (abnormal closure): unexpected EOF")
+	close(conn.in)
+
+	_, err := FromWebSocket(conn, Keepalive(0, 0)).ToSlice()
+	assert.EqualError(t, err, "websocket: close 1006 (abnormal closure): unexpected EOF")
+}
+
+func TestFromWebSocketKeepalive(t *testing.T) {
+	conn := newFakeConn()
+	o := FromWebSocket(conn, Keepalive(time.Millisecond, time.Second))
+
+	for deadline := time.Now().Add(time.Second); len(conn.controls()) < 2 && time.Now().Before(deadline); {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, Message{Type: PingMessage}, conn.controls()[0])
+	conn.mu.Lock()
+	assert.True(t, conn.deadline.After(time.Now()))
+	conn.mu.Unlock()
+
+	conn.readErr = errors.New("i/o timeout")
+	close(conn.in)
+	_, err := o.ToSlice()
+	assert.EqualError(t, err, "i/o timeout")
+}
+
+func TestToWebSocket(t *testing.T) {
+	conn := newFakeConn()
+	sub := <-ToWebSocket(conn, observable.Just("text", []byte{1}, map[string]int{"a": 1}, Message{Type: TextMessage, Data: []byte("raw")}))
+
+	assert.Nil(t, sub.Err())
+	assert.Equal(t, []Message{
+		{Type: TextMessage, Data: []byte("text")},
+		{Type: BinaryMessage, Data: []byte{1}},
+		{Type: TextMessage, Data: []byte(`{"a":1}`)},
+		{Type: TextMessage, Data: []byte("raw")},
+	}, conn.written)
+	assert.Equal(t, []Message{{Type: CloseMessage, Data: []byte{0x03, 0xe8}}}, conn.controls())
+}
+
+func TestToWebSocketError(t *testing.T) {
+	conn := newFakeConn()
+	sub := <-ToWebSocket(conn, observable.Just("a", errors.New("bang")))
+	assert.EqualError(t, sub.Err(), "bang")
+	assert.Equal(t, []Message{{Type: CloseMessage, Data: append([]byte{0x03, 0xf3}, "bang"...)}}, conn.controls())
+
+	conn = newFakeConn()
+	sub = <-ToWebSocket(conn, observable.Just(Message{Type: -1}, "never"))
+	assert.EqualError(t, sub.Err(), "broken pipe")
+	assert.Empty(t, conn.controls())
+}
+
+func TestCloseMessageTruncation(t *testing.T) {
+	msg := closeMessage(CloseInternalError, strings.Repeat("é", 100))
+	assert.Equal(t, 2+122, len(msg))
+	assert.True(t, utf8.Valid(msg[2:]))
+
+	msg = closeMessage(CloseInternalError, strings.Repeat("x", 200))
+	assert.Equal(t, 125, len(msg))
+}
@@ -0,0 +1,207 @@
+// Package scheduler provides Schedulers which run the tasks of Observable
+// operators on a shared set of goroutines.
+package scheduler
+
+import (
+	"context"
+	"runtime"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+)
+
+// Scheduler runs tasks, possibly concurrently.
+type Scheduler interface {
+	Schedule(task func())
+}
+
+// WorkStealing is a Scheduler backed by a fixed number of workers, each
+// owning a local deque of tasks. A worker runs its own tasks newest-first and,
+// when it runs out, steals the oldest tasks from the other workers before
+// going to sleep.
+type WorkStealing struct {
+	workers []*deque
+	next    uint32
+	pending int64
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	closed bool
+	wg     sync.WaitGroup
+}
+
+// NewWorkStealing creates a WorkStealing scheduler with n workers. If n is
+// zero, one worker per GOMAXPROCS is started.
+func NewWorkStealing(n uint) *WorkStealing {
+	if n == 0 {
+		n = uint(runtime.GOMAXPROCS(0))
+	}
+	ws := &WorkStealing{workers: make([]*deque, n)}
+	ws.cond = sync.NewCond(&ws.mu)
+	for i := range ws.workers {
+		ws.workers[i] = new(deque)
+	}
+	ws.wg.Add(int(n))
+	for i := range ws.workers {
+		go ws.run(i)
+	}
+	return ws
+}
+
+// Schedule queues a task on one of the workers. Schedule must not be called
+// after Close.
+func (ws *WorkStealing) Schedule(task func()) {
+	task = onSchedule(task)
+	i := atomic.AddUint32(&ws.next, 1) % uint32(len(ws.workers))
+	// Count the task before it can be taken, so that pending never goes
+	// negative.
+	atomic.AddInt64(&ws.pending, 1)
+	ws.workers[i].pushBack(task)
+
+	ws.mu.Lock()
+	ws.cond.Signal()
+	ws.mu.Unlock()
+}
+
+// Close stops the workers once every queued task has run, and waits for
+// them to exit.
+func (ws *WorkStealing) Close() {
+	ws.mu.Lock()
+	ws.closed = true
+	ws.cond.Broadcast()
+	ws.mu.Unlock()
+	ws.wg.Wait()
+}
+
+func (ws *WorkStealing) run(id int) {
+	defer ws.wg.Done()
+	for {
+		task, ok := ws.take(id)
+		if !ok {
+			return
+		}
+		task()
+	}
+}
+
+// take returns the next task for worker id, blocking until one is
+// available. It returns false when the scheduler is closed and drained.
+func (ws *WorkStealing) take(id int) (func(), bool) {
+	n := len(ws.workers)
+	for {
+		if task := ws.workers[id].popBack(); task != nil {
+			atomic.AddInt64(&ws.pending, -1)
+			return task, true
+		}
+		for i := 1; i < n; i++ {
+			if task := ws.workers[(id+i)%n].popFront(); task != nil {
+				atomic.AddInt64(&ws.pending, -1)
+				return task, true
+			}
+		}
+
+		ws.mu.Lock()
+		for atomic.LoadInt64(&ws.pending) == 0 && !ws.closed {
+			ws.cond.Wait()
+		}
+		done := ws.closed && atomic.LoadInt64(&ws.pending) == 0
+		ws.mu.Unlock()
+		if done {
+			return nil, false
+		}
+	}
+}
+
+// ScheduleHook observes or wraps every task given to a WorkStealing
+// scheduler, for instance to measure or delay it.
+type ScheduleHook func(task func()) func()
+
+var (
+	hooksMu sync.Mutex
+	hooks   atomic.Value
+)
+
+func init() {
+	hooks.Store([]ScheduleHook(nil))
+}
+
+// OnSchedule adds a hook called with every task scheduled. Hooks run in the
+// order they were added, each one receiving the result of the previous one.
+func OnSchedule(hook ScheduleHook) {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	current := hooks.Load().([]ScheduleHook)
+	hooks.Store(append(current[:len(current):len(current)], hook))
+}
+
+// ResetHooks removes every ScheduleHook.
+func ResetHooks() {
+	hooksMu.Lock()
+	defer hooksMu.Unlock()
+	hooks.Store([]ScheduleHook(nil))
+}
+
+func onSchedule(task func()) func() {
+	for _, hook := range hooks.Load().([]ScheduleHook) {
+		task = hook(task)
+	}
+	return task
+}
+
+// deque is a mutex guarded double-ended queue of tasks.
+type deque struct {
+	mu    sync.Mutex
+	tasks []func()
+}
+
+func (d *deque) pushBack(task func()) {
+	d.mu.Lock()
+	d.tasks = append(d.tasks, task)
+	d.mu.Unlock()
+}
+
+func (d *deque) popBack() func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tasks) == 0 {
+		return nil
+	}
+	task := d.tasks[len(d.tasks)-1]
+	d.tasks[len(d.tasks)-1] = nil
+	d.tasks = d.tasks[:len(d.tasks)-1]
+	return task
+}
+
+func (d *deque) popFront() func() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if len(d.tasks) == 0 {
+		return nil
+	}
+	task := d.tasks[0]
+	d.tasks[0] = nil
+	d.tasks = d.tasks[1:]
+	return task
+}
+
+// labeled is a Scheduler running the tasks of another one with pprof labels.
+type labeled struct {
+	sched  Scheduler
+	labels pprof.LabelSet
+}
+
+// Labeled returns a Scheduler running its tasks on sched with the given
+// pprof labels, given as key-value pairs, set on the running goroutine, so
+// that profiles attribute the work of shared workers to the stream which
+// scheduled it.
+func Labeled(sched Scheduler, labels ...string) Scheduler {
+	return labeled{sched: sched, labels: pprof.Labels(labels...)}
+}
+
+func (l labeled) Schedule(task func()) {
+	l.sched.Schedule(func() {
+		pprof.Do(context.Background(), l.labels, func(context.Context) {
+			task()
+		})
+	})
+}
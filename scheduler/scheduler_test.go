@@ -0,0 +1,106 @@
+package scheduler
+
+import (
+	"bytes"
+	"runtime/pprof"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkStealingImplementsScheduler(t *testing.T) {
+	assert.Implements(t, (*Scheduler)(nil), NewWorkStealing(1))
+}
+
+func TestWorkStealingRunsAllTasks(t *testing.T) {
+	ws := NewWorkStealing(4)
+	defer ws.Close()
+
+	var count int64
+	var wg sync.WaitGroup
+	wg.Add(1000)
+	for i := 0; i < 1000; i++ {
+		ws.Schedule(func() {
+			atomic.AddInt64(&count, 1)
+			wg.Done()
+		})
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 1000, atomic.LoadInt64(&count))
+}
+
+func TestWorkStealingNestedSchedule(t *testing.T) {
+	ws := NewWorkStealing(2)
+	defer ws.Close()
+
+	var count int64
+	var wg sync.WaitGroup
+	wg.Add(100)
+	for i := 0; i < 10; i++ {
+		ws.Schedule(func() {
+			for j := 0; j < 10; j++ {
+				ws.Schedule(func() {
+					atomic.AddInt64(&count, 1)
+					wg.Done()
+				})
+			}
+		})
+	}
+	wg.Wait()
+
+	assert.EqualValues(t, 100, atomic.LoadInt64(&count))
+}
+
+func TestWorkStealingCloseDrainsQueue(t *testing.T) {
+	ws := NewWorkStealing(0)
+
+	var count int64
+	for i := 0; i < 100; i++ {
+		ws.Schedule(func() {
+			atomic.AddInt64(&count, 1)
+		})
+	}
+	ws.Close()
+
+	assert.EqualValues(t, 100, atomic.LoadInt64(&count))
+}
+
+func TestLabeled(t *testing.T) {
+	ws := NewWorkStealing(1)
+	defer ws.Close()
+
+	profile := make(chan string, 1)
+	Labeled(ws, "rxgo.observable", "orders").Schedule(func() {
+		var buf bytes.Buffer
+		pprof.Lookup("goroutine").WriteTo(&buf, 1)
+		profile <- buf.String()
+	})
+	assert.Contains(t, <-profile, `"rxgo.observable":"orders"`)
+}
+
+func TestOnSchedule(t *testing.T) {
+	defer ResetHooks()
+	var wrapped int64
+	OnSchedule(func(task func()) func() {
+		return func() {
+			atomic.AddInt64(&wrapped, 1)
+			task()
+		}
+	})
+
+	ws := NewWorkStealing(2)
+	for i := 0; i < 10; i++ {
+		ws.Schedule(func() {})
+	}
+	ws.Close()
+	assert.EqualValues(t, 10, atomic.LoadInt64(&wrapped))
+
+	ResetHooks()
+	ws = NewWorkStealing(1)
+	ws.Schedule(func() {})
+	ws.Close()
+	assert.EqualValues(t, 10, atomic.LoadInt64(&wrapped))
+}
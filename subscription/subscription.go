@@ -7,6 +7,9 @@ type Subscription struct {
 	SubscribeAt   time.Time
 	UnsubscribeAt time.Time
 	Error         error
+	// Dropped is the number of items a backpressure strategy discarded
+	// instead of delivering them to the subscriber.
+	Dropped uint64
 	//term          chan struct{}
 }
 
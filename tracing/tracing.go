@@ -0,0 +1,167 @@
+// Package tracing lets a span context ride along with the items of an
+// Observable, and creates child spans around the significant stages of a
+// pipeline. Its Tracer interface is small enough to be implemented on top
+// of an OpenTelemetry trace.Tracer in a few lines:
+//
+//	type otelTracer struct{ trace.Tracer }
+//
+//	func (t otelTracer) Start(ctx context.Context, name string) (context.Context, tracing.Span) {
+//		ctx, span := t.Tracer.Start(ctx, name)
+//		return ctx, otelSpan{span}
+//	}
+//
+//	type otelSpan struct{ trace.Span }
+//
+//	func (s otelSpan) End() { s.Span.End() }
+//
+//	func (s otelSpan) RecordError(err error) { s.Span.RecordError(err) }
+package tracing
+
+import (
+	"context"
+
+	"github.com/reactivex/rxgo/observable"
+)
+
+// Span is a unit of traced work.
+type Span interface {
+	End()
+	RecordError(err error)
+}
+
+// Tracer starts Spans as children of the span carried by a context.
+type Tracer interface {
+	Start(ctx context.Context, name string) (context.Context, Span)
+}
+
+// Traced is an item carrying the context of the span it belongs to.
+type Traced struct {
+	Context context.Context
+	Value   interface{}
+}
+
+// unwrap returns the context and value of item, using ctx for items which
+// aren't Traced.
+func unwrap(ctx context.Context, item interface{}) (context.Context, interface{}) {
+	if t, ok := item.(Traced); ok {
+		return t.Context, t.Value
+	}
+	return ctx, item
+}
+
+// WithContext returns an Observable emitting each item of o as a Traced
+// carrying ctx, the parent of the spans started downstream. Errors are
+// passed on as is.
+func WithContext(ctx context.Context, o observable.Observable) observable.Observable {
+	out := make(chan interface{})
+	go func() {
+		for item := range o {
+			if _, ok := item.(error); !ok {
+				_, value := unwrap(ctx, item)
+				item = Traced{Context: ctx, Value: value}
+			}
+			out <- item
+		}
+		close(out)
+	}()
+	return observable.Observable(out)
+}
+
+// Values returns an Observable emitting the values of the Traced items of o.
+func Values(o observable.Observable) observable.Observable {
+	return o.Map(func(item interface{}) interface{} {
+		_, value := unwrap(nil, item)
+		return value
+	})
+}
+
+// Map applies apply to the value of each item of o within a child span
+// named name, and emits its result as a Traced carrying the span context.
+// An error returned by apply is recorded on the span and emitted.
+func Map(o observable.Observable, tracer Tracer, name string, apply func(context.Context, interface{}) (interface{}, error)) observable.Observable {
+	out := make(chan interface{})
+	go func() {
+		for item := range o {
+			if _, ok := item.(error); ok {
+				out <- item
+				continue
+			}
+			ctx, value := unwrap(context.Background(), item)
+			ctx, span := tracer.Start(ctx, name)
+			result, err := apply(ctx, value)
+			if err != nil {
+				span.RecordError(err)
+				span.End()
+				out <- err
+				continue
+			}
+			span.End()
+			out <- Traced{Context: ctx, Value: result}
+		}
+		close(out)
+	}()
+	return observable.Observable(out)
+}
+
+// FlatMap calls apply for the value of each item of o within a child span
+// named name, and emits the items of the returned Observable, one inner
+// Observable after the other, as Traced carrying the span context. The
+// span ends when the inner Observable completes, recording its error if
+// any.
+func FlatMap(o observable.Observable, tracer Tracer, name string, apply func(context.Context, interface{}) observable.Observable) observable.Observable {
+	out := make(chan interface{})
+	go func() {
+		for item := range o {
+			if _, ok := item.(error); ok {
+				out <- item
+				continue
+			}
+			ctx, value := unwrap(context.Background(), item)
+			ctx, span := tracer.Start(ctx, name)
+			for inner := range apply(ctx, value) {
+				if err, ok := inner.(error); ok {
+					span.RecordError(err)
+					out <- err
+					continue
+				}
+				out <- Traced{Context: ctx, Value: inner}
+			}
+			span.End()
+		}
+		close(out)
+	}()
+	return observable.Observable(out)
+}
+
+// Retry subscribes to the Observable returned by factory, and resubscribes
+// up to attempts times while it fails. Each subscription runs within a
+// child span of ctx named name, recording its error if any. The items of
+// every attempt are emitted, and the error of the last one.
+func Retry(ctx context.Context, tracer Tracer, name string, attempts uint, factory func(context.Context) observable.Observable) observable.Observable {
+	out := make(chan interface{})
+	go func() {
+		for attempt := uint(0); ; attempt++ {
+			spanCtx, span := tracer.Start(ctx, name)
+			var failure error
+			for item := range factory(spanCtx) {
+				if err, ok := item.(error); ok {
+					failure = err
+					break
+				}
+				out <- Traced{Context: spanCtx, Value: item}
+			}
+			if failure == nil {
+				span.End()
+				break
+			}
+			span.RecordError(failure)
+			span.End()
+			if attempt >= attempts {
+				out <- failure
+				break
+			}
+		}
+		close(out)
+	}()
+	return observable.Observable(out)
+}
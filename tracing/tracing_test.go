@@ -0,0 +1,117 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+type spanKey struct{}
+
+type span struct {
+	name   string
+	parent string
+	err    error
+	ended  bool
+}
+
+func (s *span) End() {
+	s.ended = true
+}
+
+func (s *span) RecordError(err error) {
+	s.err = err
+}
+
+type tracer struct {
+	mu    sync.Mutex
+	spans []*span
+}
+
+func (t *tracer) Start(ctx context.Context, name string) (context.Context, Span) {
+	s := &span{name: name}
+	if parent, ok := ctx.Value(spanKey{}).(*span); ok {
+		s.parent = parent.name
+	}
+	t.mu.Lock()
+	t.spans = append(t.spans, s)
+	t.mu.Unlock()
+	return context.WithValue(ctx, spanKey{}, s), s
+}
+
+func TestMap(t *testing.T) {
+	tr := new(tracer)
+	parent, _ := tr.Start(context.Background(), "request")
+
+	o := WithContext(parent, observable.Just(1, 2))
+	o = Map(o, tr, "double", func(ctx context.Context, item interface{}) (interface{}, error) {
+		if item.(int) == 2 {
+			return nil, errors.New("bang")
+		}
+		return item.(int) * 2, nil
+	})
+
+	var items []interface{}
+	for item := range o {
+		items = append(items, item)
+	}
+	assert.Len(t, items, 2)
+	assert.Equal(t, 2, items[0].(Traced).Value)
+	assert.EqualError(t, items[1].(error), "bang")
+
+	assert.Len(t, tr.spans, 3)
+	for _, s := range tr.spans[1:] {
+		assert.Equal(t, "double", s.name)
+		assert.Equal(t, "request", s.parent)
+		assert.True(t, s.ended)
+	}
+	assert.Nil(t, tr.spans[1].err)
+	assert.EqualError(t, tr.spans[2].err, "bang")
+}
+
+func TestFlatMap(t *testing.T) {
+	tr := new(tracer)
+	parent, _ := tr.Start(context.Background(), "request")
+
+	o := FlatMap(WithContext(parent, observable.Just(1, 2)), tr, "expand", func(ctx context.Context, item interface{}) observable.Observable {
+		return observable.Just(item, item.(int)*10)
+	})
+	items, err := Values(o).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{1, 10, 2, 20}, items)
+
+	assert.Len(t, tr.spans, 3)
+	assert.Equal(t, "request", tr.spans[1].parent)
+	assert.Equal(t, "request", tr.spans[2].parent)
+}
+
+func TestRetry(t *testing.T) {
+	tr := new(tracer)
+	parent, _ := tr.Start(context.Background(), "request")
+
+	calls := 0
+	o := Retry(parent, tr, "fetch", 3, func(ctx context.Context) observable.Observable {
+		calls++
+		if calls < 3 {
+			return observable.Throw(errors.New("unavailable"))
+		}
+		return observable.Just("ok")
+	})
+	items, err := Values(o).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"ok"}, items)
+
+	assert.Len(t, tr.spans, 4)
+	assert.EqualError(t, tr.spans[1].err, "unavailable")
+	assert.EqualError(t, tr.spans[2].err, "unavailable")
+	assert.Nil(t, tr.spans[3].err)
+
+	_, err = Retry(parent, tr, "fetch", 1, func(ctx context.Context) observable.Observable {
+		return observable.Throw(errors.New("down"))
+	}).ToSlice()
+	assert.EqualError(t, err, "down")
+}
@@ -0,0 +1,144 @@
+//go:build go1.18
+
+// Package typed provides Observable[T], a type-safe layer over the
+// interface{}-based observable package. Pipelines built with it need no type
+// assertions, and convert to and from untyped Observables at any point.
+package typed
+
+import (
+	"fmt"
+
+	"github.com/reactivex/rxgo/errors"
+	"github.com/reactivex/rxgo/observable"
+	"github.com/reactivex/rxgo/observer"
+	"github.com/reactivex/rxgo/subscription"
+)
+
+// Observable is a stream of items of type T, terminated by completion or by
+// an error.
+type Observable[T any] struct {
+	source observable.Observable
+}
+
+// Observer is a group of typed handlers. Any of them may be nil.
+type Observer[T any] struct {
+	OnNext  func(T)
+	OnError func(error)
+	OnDone  func()
+}
+
+// From wraps an untyped Observable. An item which is not a T terminates the
+// typed Observable with an ObservableError.
+func From[T any](source observable.Observable) Observable[T] {
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		for item := range source {
+			if _, isErr := item.(error); !isErr {
+				if _, ok := item.(T); !ok {
+					var zero T
+					out <- errors.New(errors.ObservableError, fmt.Sprintf("%v is not a %T", item, zero))
+					return
+				}
+			}
+			out <- item
+		}
+	}()
+	return Observable[T]{source: observable.Observable(out)}
+}
+
+// wrap adopts an untyped Observable known to only emit Ts and errors.
+func wrap[T any](source observable.Observable) Observable[T] {
+	return Observable[T]{source: source}
+}
+
+// Just creates an Observable emitting the given items.
+func Just[T any](items ...T) Observable[T] {
+	return wrap[T](observable.FromSlice(items))
+}
+
+// FromChannel creates an Observable emitting the items received on ch, which
+// completes when ch is closed.
+func FromChannel[T any](ch <-chan T) Observable[T] {
+	return wrap[T](observable.FromChannelOf(ch))
+}
+
+// Untyped returns the underlying interface{}-based Observable.
+func (o Observable[T]) Untyped() observable.Observable {
+	return o.source
+}
+
+// Filter emits only the items for which apply returns true.
+func (o Observable[T]) Filter(apply func(T) bool) Observable[T] {
+	return wrap[T](o.source.Filter(func(item interface{}) bool {
+		if _, isErr := item.(error); isErr {
+			return true
+		}
+		return apply(item.(T))
+	}))
+}
+
+// Take emits only the first n items.
+func (o Observable[T]) Take(n uint) Observable[T] {
+	return wrap[T](o.source.Take(n))
+}
+
+// Pipe applies untyped operators which preserve the item type, such as
+// Distinct or Debug, to the Observable.
+func (o Observable[T]) Pipe(ops ...observable.Operator) Observable[T] {
+	return From[T](o.source.Pipe(ops...))
+}
+
+// Subscribe subscribes a typed Observer to the Observable.
+func (o Observable[T]) Subscribe(ob Observer[T]) <-chan subscription.Subscription {
+	untyped := observer.Observer{}
+	if ob.OnNext != nil {
+		untyped.NextHandler = func(item interface{}) {
+			ob.OnNext(item.(T))
+		}
+	}
+	untyped.ErrHandler = ob.OnError
+	untyped.DoneHandler = ob.OnDone
+	return o.source.Subscribe(untyped)
+}
+
+// ToSlice collects the items into a slice, returning the error which
+// terminated the Observable, if any, along with the items before it.
+func (o Observable[T]) ToSlice() ([]T, error) {
+	var items []T
+	sub := <-o.Subscribe(Observer[T]{
+		OnNext: func(item T) {
+			items = append(items, item)
+		},
+	})
+	return items, sub.Err()
+}
+
+// Map maps each item of o with apply.
+func Map[T, R any](o Observable[T], apply func(T) R) Observable[R] {
+	return wrap[R](o.source.Map(func(item interface{}) interface{} {
+		if _, isErr := item.(error); isErr {
+			return item
+		}
+		return apply(item.(T))
+	}))
+}
+
+// Reduce folds the items of o into a single value starting from initial,
+// emitted once o completes.
+func Reduce[T, R any](o Observable[T], initial R, apply func(R, T) R) Observable[R] {
+	out := make(chan interface{})
+	go func() {
+		defer close(out)
+		acc := initial
+		for item := range o.source {
+			if _, isErr := item.(error); isErr {
+				out <- item
+				return
+			}
+			acc = apply(acc, item.(T))
+		}
+		out <- acc
+	}()
+	return wrap[R](observable.Observable(out))
+}
@@ -0,0 +1,78 @@
+//go:build go1.18
+
+package typed
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+
+	"github.com/reactivex/rxgo/observable"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTypedPipeline(t *testing.T) {
+	evens := Just(1, 2, 3, 4, 5, 6).Filter(func(n int) bool {
+		return n%2 == 0
+	})
+	labels, err := Map(evens, strconv.Itoa).Take(2).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"2", "4"}, labels)
+
+	sum, err := Reduce(Just(1, 2, 3), 0, func(acc, n int) int {
+		return acc + n
+	}).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{6}, sum)
+}
+
+func TestTypedFromChannel(t *testing.T) {
+	ch := make(chan string, 2)
+	ch <- "a"
+	ch <- "b"
+	close(ch)
+
+	items, err := FromChannel(ch).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a", "b"}, items)
+}
+
+func TestTypedAdapters(t *testing.T) {
+	items, err := From[int](observable.Just(1, 2)).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+
+	items, err = From[int](observable.Just(1, "two")).ToSlice()
+	assert.Error(t, err)
+	assert.Equal(t, []int{1}, items)
+
+	untyped, err := Just("x").Untyped().ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []interface{}{"x"}, untyped)
+
+	items, err = Just(1, 1, 2).Pipe(func(o observable.Observable) observable.Observable {
+		return o.DistinctUntilChanged(func(item interface{}) interface{} { return item })
+	}).ToSlice()
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 2}, items)
+}
+
+func TestTypedSubscribe(t *testing.T) {
+	var failure error
+	done := false
+	sub := <-From[int](observable.Just(1, errors.New("bang"))).Subscribe(Observer[int]{
+		OnError: func(err error) { failure = err },
+		OnDone:  func() { done = true },
+	})
+	assert.EqualError(t, sub.Err(), "bang")
+	assert.EqualError(t, failure, "bang")
+	assert.False(t, done)
+}
+
+func TestTypedFilterPassesErrors(t *testing.T) {
+	items, err := From[int](observable.Just(1, 2, errors.New("bang"))).Filter(func(n int) bool {
+		return n > 1
+	}).ToSlice()
+	assert.EqualError(t, err, "bang")
+	assert.Equal(t, []int{2}, items)
+}